@@ -1,12 +1,19 @@
 package main
 
 import (
+	"log"
+
 	"github.com/gin-gonic/gin"
+	"github.com/k6zma/DiscreteSolver/internal/api/handlers"
 	"github.com/k6zma/DiscreteSolver/internal/api/middlewares"
 	"github.com/k6zma/DiscreteSolver/pkg/api/routers"
 )
 
 func main() {
+	if err := handlers.ValidateGraphRenderer(); err != nil {
+		log.Fatal(err)
+	}
+
 	router := gin.New()
 
 	router.SetTrustedProxies(nil)
@@ -1,38 +1,108 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"os"
 
 	"github.com/gin-gonic/gin"
 	"github.com/k6zma/DiscreteSolver/internal/api/models"
 	"github.com/k6zma/DiscreteSolver/internal/mathalgos"
+	"github.com/k6zma/DiscreteSolver/internal/utils"
 )
 
-func GetRelationPropertiesHandler(c *gin.Context) {
+func graphRenderer() string {
+	renderer := os.Getenv("GRAPH_RENDERER")
+	if renderer == "" {
+		return mathalgos.BuiltinRenderer
+	}
+
+	return renderer
+}
+
+// ValidateGraphRenderer checks the GRAPH_RENDERER setting so that a typo is
+// reported at startup instead of on the first image request.
+func ValidateGraphRenderer() error {
+	return mathalgos.ValidateRenderer(graphRenderer())
+}
+
+// renderErrorResponse treats text the image font cannot draw as a client
+// error and everything else as a rendering failure.
+func renderErrorResponse(c *gin.Context, err error) {
+	var glyphErr *mathalgos.GlyphError
+	if errors.As(err, &glyphErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+func bindBinaryRelationModel(c *gin.Context) (models.BinaryRelationModel, bool) {
 	var model models.BinaryRelationModel
 	if err := c.ShouldBindJSON(&model); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return model, false
+	}
+
+	model, err := mathalgos.NormalizeRelationModel(model)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return model, false
+	}
+
+	return model, true
+}
+
+func GetRelationPropertiesHandler(c *gin.Context) {
+	model, ok := bindBinaryRelationModel(c)
+	if !ok {
 		return
 	}
 
-	properties := mathalgos.GetRelationProperties(model)
-	c.JSON(http.StatusOK, gin.H{"properties": properties})
+	report := mathalgos.GetRelationPropertiesReport(model)
+
+	response := models.RelationPropertiesResponse{
+		Properties:    mathalgos.GetRelationProperties(model),
+		Report:        make([]models.RelationProperty, len(report)),
+		SetOfElements: model.SetOfElements,
+		Matrix:        model.Matrix,
+	}
+	for i, property := range report {
+		response.Report[i] = models.RelationProperty(property)
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 func GenerateRelationGraphHandler(c *gin.Context) {
-	var model models.BinaryRelationModel
-	if err := c.ShouldBindJSON(&model); err != nil {
+	var request models.RelationGraphRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	graph := mathalgos.NewBinaryRelationGraph(model)
-	imageData, err := graph.GenerateImage()
+	model, err := mathalgos.NormalizeRelationModel(request.BinaryRelationModel)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := mathalgos.ValidateGraphStyle(request.Style); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	format, ok := utils.NegotiateFormat(c, request.Format, "png", "svg", "pdf", "dot")
+	if !ok {
+		return
+	}
 
+	graph := mathalgos.NewStyledBinaryRelationGraph(model, request.Style)
+	imageData, err := graph.Render(format, graphRenderer())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		renderErrorResponse(c, err)
 		return
 	}
 
-	c.Data(http.StatusOK, "image/png", imageData)
+	utils.DataResponse(c, format, imageData)
 }
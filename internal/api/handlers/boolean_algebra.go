@@ -1,13 +1,25 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/k6zma/DiscreteSolver/internal/api/models"
 	"github.com/k6zma/DiscreteSolver/internal/mathalgos"
+	"github.com/k6zma/DiscreteSolver/internal/utils"
 )
 
+func expressionErrorResponse(c *gin.Context, err error) {
+	var expressionErr *mathalgos.ExpressionError
+	if errors.As(err, &expressionErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "position": expressionErr.Position})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
+
 func GenerateTruthTableHandler(c *gin.Context) {
 	var request models.GenerateTruthTableRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
@@ -15,12 +27,247 @@ func GenerateTruthTableHandler(c *gin.Context) {
 		return
 	}
 
-	generator := mathalgos.NewTruthTableGenerator(request.Expression)
-	imageData, err := generator.CreateTruthTableImage()
+	generator, err := mathalgos.NewOrderedTruthTableGenerator(request.Expression, request.Variables)
+	if err != nil {
+		expressionErrorResponse(c, err)
+		return
+	}
+
+	format, ok := utils.NegotiateFormat(c, request.Format, "json", "png", "csv")
+	if !ok {
+		return
+	}
+
+	if format == "png" {
+		imageData, err := generator.CreateTruthTableImage()
+		if err != nil {
+			expressionErrorResponse(c, err)
+			return
+		}
+
+		utils.DataResponse(c, format, imageData)
+		return
+	}
+
+	table, err := generator.BuildTruthTable()
+	if err != nil {
+		expressionErrorResponse(c, err)
+		return
+	}
+
+	if format == "csv" {
+		csvData, err := table.CSV()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		utils.DataResponse(c, format, csvData)
+		return
+	}
+
+	response := models.TruthTableResponse(table)
+	c.JSON(http.StatusOK, response)
+}
+
+func GetNormalFormsHandler(c *gin.Context) {
+	var request models.NormalFormsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	generator, err := mathalgos.NewOrderedTruthTableGenerator(request.Expression, request.Variables)
+	if err != nil {
+		expressionErrorResponse(c, err)
+		return
+	}
+
+	table, err := generator.BuildTruthTable()
+	if err != nil {
+		expressionErrorResponse(c, err)
+		return
+	}
+
+	response := models.NormalFormsResponse(mathalgos.BuildPerfectNormalForms(table))
+	c.JSON(http.StatusOK, response)
+}
+
+func toImplicantModels(implicants []mathalgos.Implicant) []models.Implicant {
+	result := make([]models.Implicant, len(implicants))
+	for i, implicant := range implicants {
+		result[i] = models.Implicant(implicant)
+	}
+	return result
+}
+
+func MinimizeHandler(c *gin.Context) {
+	var request models.MinimizationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sources := 0
+	for _, provided := range []bool{request.Expression != "", request.Vector != "", len(request.Minterms) > 0 || len(request.DontCares) > 0} {
+		if provided {
+			sources++
+		}
+	}
+	if sources != 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "exactly one of expression, vector or minterms must be provided"})
+		return
+	}
+
+	simplifier := mathalgos.NewLogicSimplifier()
+
+	var result mathalgos.Minimization
+	var err error
+	switch {
+	case request.Expression != "":
+		result, err = simplifier.MinimizeExpression(request.Expression, request.Variables)
+	case request.Vector != "":
+		result, err = simplifier.MinimizeVector(request.Vector, request.Variables)
+	default:
+		result, err = simplifier.MinimizeTerms(request.Minterms, request.DontCares, request.Variables)
+	}
+	if err != nil {
+		expressionErrorResponse(c, err)
+		return
+	}
+
+	response := models.MinimizationResponse{
+		Variables:           result.Variables,
+		Minterms:            result.Minterms,
+		DontCares:           result.DontCares,
+		PrimeImplicants:     toImplicantModels(result.PrimeImplicants),
+		EssentialImplicants: toImplicantModels(result.EssentialImplicants),
+		Chart:               result.Chart,
+		Selected:            toImplicantModels(result.Selected),
+		Exact:               result.Exact,
+		MinimalDNF:          result.MinimalDNF,
+		MinimalCNF:          result.MinimalCNF,
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func GenerateKarnaughMapHandler(c *gin.Context) {
+	var request models.KarnaughMapRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	format, ok := utils.NegotiateFormat(c, request.Format, "json", "png", "svg", "pdf")
+	if !ok {
+		return
+	}
+
+	kmap, err := mathalgos.BuildKarnaughMap(request.Expression, request.Variables)
+	if err != nil {
+		expressionErrorResponse(c, err)
+		return
+	}
+
+	if format != "json" {
+		imageData, err := kmap.Render(format)
+		if err != nil {
+			renderErrorResponse(c, err)
+			return
+		}
+
+		utils.DataResponse(c, format, imageData)
+		return
+	}
+
+	response := models.KarnaughMapResponse{
+		Variables:       kmap.Variables,
+		RowVariables:    kmap.RowVariables,
+		ColumnVariables: kmap.ColumnVariables,
+		RowLabels:       kmap.RowLabels,
+		ColumnLabels:    kmap.ColumnLabels,
+		Values:          kmap.Values,
+		Minterms:        kmap.Minterms,
+		Groups:          make([]models.KarnaughGroup, len(kmap.Groups)),
+		MinimalDNF:      kmap.MinimalDNF,
+	}
+	for i, group := range kmap.Groups {
+		response.Groups[i] = models.KarnaughGroup(group)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func GetZhegalkinPolynomialHandler(c *gin.Context) {
+	var request models.BooleanFunctionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	variables, values, err := mathalgos.FunctionValues(request.Expression, request.Vector, request.Variables)
+	if err != nil {
+		expressionErrorResponse(c, err)
+		return
+	}
+
+	response := models.ZhegalkinResponse(mathalgos.BuildZhegalkinPolynomial(variables, values))
+	c.JSON(http.StatusOK, response)
+}
+
+func GetPostClassesHandler(c *gin.Context) {
+	var request models.PostClassesRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	table := make([]mathalgos.PostClassMembership, len(request.Functions))
+	for i, function := range request.Functions {
+		variables, values, err := mathalgos.FunctionValues(function.Expression, function.Vector, function.Variables)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("functions[%d]: %v", i, err)})
+			return
+		}
+
+		label := function.Expression
+		if label == "" {
+			label = function.Vector
+		}
+		table[i] = mathalgos.ClassifyPostFunction(label, variables, values)
+	}
+
+	analysis, err := mathalgos.AnalyzePostClasses(table)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := models.PostClassesResponse{
+		Table:             make([]models.PostClassMembership, len(analysis.Table)),
+		IsComplete:        analysis.IsComplete,
+		ContainingClasses: analysis.ContainingClasses,
+		MinimalSubsystem:  analysis.MinimalSubsystem,
+	}
+	for i, membership := range analysis.Table {
+		response.Table[i] = models.PostClassMembership(membership)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func CompareBooleanExpressionsHandler(c *gin.Context) {
+	var request models.BooleanEquivalenceRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comparison, err := mathalgos.CompareBooleanExpressions(request.First, request.Second, request.Variables)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		expressionErrorResponse(c, err)
 		return
 	}
 
-	c.Data(http.StatusOK, "image/png", imageData)
+	c.JSON(http.StatusOK, models.BooleanEquivalenceResponse(comparison))
 }
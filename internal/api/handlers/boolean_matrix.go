@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+	"github.com/k6zma/DiscreteSolver/internal/mathalgos"
+)
+
+func GetRelationMatrixHandler(c *gin.Context) {
+	model, ok := bindBinaryRelationModel(c)
+	if !ok {
+		return
+	}
+
+	analysis := mathalgos.AnalyzeRelationMatrix(model)
+
+	response := models.RelationMatrixResponse(analysis)
+	c.JSON(http.StatusOK, response)
+}
+
+func GenerateRelationMatrixImageHandler(c *gin.Context) {
+	model, ok := bindBinaryRelationModel(c)
+	if !ok {
+		return
+	}
+
+	imageData, err := mathalgos.CreateRelationMatrixImage(model)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Data(http.StatusOK, "image/png", imageData)
+}
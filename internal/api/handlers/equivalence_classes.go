@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+	"github.com/k6zma/DiscreteSolver/internal/mathalgos"
+)
+
+func GetEquivalenceClassesHandler(c *gin.Context) {
+	model, ok := bindBinaryRelationModel(c)
+	if !ok {
+		return
+	}
+
+	classes := mathalgos.GetEquivalenceClasses(model)
+
+	response := models.EquivalenceClassesResponse{
+		IsEquivalence:    classes.IsEquivalence,
+		FailedProperties: make([]models.RelationProperty, len(classes.FailedProperties)),
+		Classes:          classes.Classes,
+		Representatives:  classes.Representatives,
+	}
+	for i, property := range classes.FailedProperties {
+		response.FailedProperties[i] = models.RelationProperty(property)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
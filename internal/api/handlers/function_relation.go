@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+	"github.com/k6zma/DiscreteSolver/internal/mathalgos"
+)
+
+func GetFunctionPropertiesHandler(c *gin.Context) {
+	var model models.BinaryRelationModel
+	if err := c.ShouldBindJSON(&model); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	model, err := mathalgos.NormalizeFunctionModel(model)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	analysis, err := mathalgos.AnalyzeFunction(model)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := models.FunctionPropertiesResponse(analysis)
+	c.JSON(http.StatusOK, response)
+}
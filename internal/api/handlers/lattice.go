@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+	"github.com/k6zma/DiscreteSolver/internal/mathalgos"
+)
+
+func GetLatticeHandler(c *gin.Context) {
+	model, ok := bindBinaryRelationModel(c)
+	if !ok {
+		return
+	}
+
+	analysis, err := mathalgos.AnalyzeLattice(model)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := models.LatticeResponse(analysis)
+	c.JSON(http.StatusOK, response)
+}
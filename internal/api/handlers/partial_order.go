@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+	"github.com/k6zma/DiscreteSolver/internal/mathalgos"
+	"github.com/k6zma/DiscreteSolver/internal/utils"
+)
+
+func GetPartialOrderHandler(c *gin.Context) {
+	model, ok := bindBinaryRelationModel(c)
+	if !ok {
+		return
+	}
+
+	analysis := mathalgos.AnalyzePartialOrder(model)
+
+	response := models.PartialOrderResponse(analysis)
+	c.JSON(http.StatusOK, response)
+}
+
+func GenerateHasseDiagramHandler(c *gin.Context) {
+	var request models.RelationGraphRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	model, err := mathalgos.NormalizeRelationModel(request.BinaryRelationModel)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := mathalgos.ValidateGraphStyle(request.Style); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	format, ok := utils.NegotiateFormat(c, request.Format, "png", "svg", "pdf", "dot")
+	if !ok {
+		return
+	}
+
+	diagram, err := mathalgos.NewHasseDiagram(model, request.Style)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	imageData, err := diagram.Render(format, graphRenderer())
+	if err != nil {
+		renderErrorResponse(c, err)
+		return
+	}
+
+	utils.DataResponse(c, format, imageData)
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+	"github.com/k6zma/DiscreteSolver/internal/mathalgos"
+)
+
+func RelationOperationHandler(c *gin.Context) {
+	var request models.RelationOperationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	first, err := mathalgos.NormalizeRelationModel(request.First)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	second := request.Second
+	if second != nil {
+		normalized, err := mathalgos.NormalizeRelationModel(*second)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		second = &normalized
+	}
+
+	relation, err := mathalgos.ApplyRelationOperation(request.Operation, first, second, request.Power)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := models.RelationOperationResponse{
+		Relation:   relation,
+		Properties: mathalgos.GetRelationProperties(relation),
+	}
+
+	if request.IncludeGraph {
+		graph := mathalgos.NewBinaryRelationGraph(relation)
+		imageData, err := graph.GenerateImage(graphRenderer())
+		if err != nil {
+			renderErrorResponse(c, err)
+			return
+		}
+		response.GraphImage = imageData
+	}
+
+	c.JSON(http.StatusOK, response)
+}
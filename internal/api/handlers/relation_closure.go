@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+	"github.com/k6zma/DiscreteSolver/internal/mathalgos"
+)
+
+func GetRelationClosureHandler(c *gin.Context) {
+	model, ok := bindBinaryRelationModel(c)
+	if !ok {
+		return
+	}
+
+	closures := mathalgos.GetRelationClosures(model)
+
+	response := models.RelationClosureResponse{
+		Reflexive:   models.RelationClosure(closures.Reflexive),
+		Symmetric:   models.RelationClosure(closures.Symmetric),
+		Transitive:  models.RelationClosure(closures.Transitive),
+		Equivalence: models.RelationClosure(closures.Equivalence),
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+	"github.com/k6zma/DiscreteSolver/internal/mathalgos"
+	"github.com/k6zma/DiscreteSolver/internal/utils"
+)
+
+func GetStrongComponentsHandler(c *gin.Context) {
+	var request models.StrongComponentsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	model, err := mathalgos.NormalizeRelationModel(request.BinaryRelationModel)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := mathalgos.ValidateGraphStyle(request.Style); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	components := mathalgos.FindStronglyConnectedComponents(model)
+
+	response := models.StrongComponentsResponse{
+		Components:          components.Components,
+		ComponentOf:         components.ComponentOf,
+		Condensation:        components.Condensation,
+		IsStronglyConnected: components.IsStronglyConnected,
+	}
+
+	if request.IncludeGraph {
+		style := mathalgos.ComponentGraphStyle(components, request.Style)
+		graph := mathalgos.NewStyledBinaryRelationGraph(components.Condensation, style)
+		imageData, err := graph.GenerateImage(graphRenderer())
+		if err != nil {
+			renderErrorResponse(c, err)
+			return
+		}
+		response.GraphImage = imageData
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func GenerateStrongComponentsGraphHandler(c *gin.Context) {
+	var request models.RelationGraphRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	model, err := mathalgos.NormalizeRelationModel(request.BinaryRelationModel)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := mathalgos.ValidateGraphStyle(request.Style); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	format, ok := utils.NegotiateFormat(c, request.Format, "png", "svg", "pdf", "dot")
+	if !ok {
+		return
+	}
+
+	components := mathalgos.FindStronglyConnectedComponents(model)
+	style := mathalgos.ComponentGraphStyle(components, request.Style)
+
+	graph := mathalgos.NewStyledBinaryRelationGraph(components.Condensation, style)
+	imageData, err := graph.Render(format, graphRenderer())
+	if err != nil {
+		renderErrorResponse(c, err)
+		return
+	}
+
+	utils.DataResponse(c, format, imageData)
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+	"github.com/k6zma/DiscreteSolver/internal/mathalgos"
+)
+
+func TopologicalSortHandler(c *gin.Context) {
+	var request models.TopologicalSortRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	model, err := mathalgos.NormalizeRelationModel(request.BinaryRelationModel)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := mathalgos.SortTopologically(model, request.Limit)
+	if err != nil {
+		var cycleErr *mathalgos.CycleError
+		if errors.As(err, &cycleErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "cycle": cycleErr.Cycle})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := models.TopologicalSortResponse(result)
+	c.JSON(http.StatusOK, response)
+}
@@ -3,4 +3,47 @@ package models
 type BinaryRelationModel struct {
 	SetOfElements  []string    `json:"set_of_elements"`
 	BinaryRelation [][2]string `json:"binary_relation"`
+	Matrix         [][]int     `json:"matrix,omitempty"`
+	Domain         []string    `json:"domain,omitempty"`
+	Codomain       []string    `json:"codomain,omitempty"`
+	Integers       []int       `json:"integers,omitempty"`
+	IntegerRange   *[2]int     `json:"integer_range,omitempty"`
+	Predicate      string      `json:"predicate,omitempty"`
+}
+
+type RelationGraphRequest struct {
+	BinaryRelationModel
+	Format string     `json:"format"`
+	Style  GraphStyle `json:"style"`
+}
+
+type RelationProperty struct {
+	Key     string      `json:"key"`
+	Name    string      `json:"name"`
+	Holds   bool        `json:"holds"`
+	Witness [][2]string `json:"witness,omitempty"`
+	Missing [][2]string `json:"missing,omitempty"`
+}
+
+type RelationPropertiesResponse struct {
+	Properties    []string           `json:"properties"`
+	Report        []RelationProperty `json:"report"`
+	SetOfElements []string           `json:"set_of_elements"`
+	Matrix        [][]int            `json:"matrix"`
+}
+
+type EdgeColor struct {
+	Pair  [2]string `json:"pair"`
+	Color string    `json:"color"`
+}
+
+type GraphStyle struct {
+	Layout         string            `json:"layout"`
+	Direction      string            `json:"direction"`
+	Title          *string           `json:"title"`
+	NodeColors     map[string]string `json:"node_colors"`
+	EdgeColors     []EdgeColor       `json:"edge_colors"`
+	HighlightPairs [][2]string       `json:"highlight_pairs"`
+	HighlightColor string            `json:"highlight_color"`
+	DPI            int               `json:"dpi"`
 }
@@ -1,5 +1,143 @@
 package models
 
 type GenerateTruthTableRequest struct {
-	Expression string `json:"expression"`
+	Expression string   `json:"expression"`
+	Variables  []string `json:"variables,omitempty"`
+	Format     string   `json:"format"`
+}
+
+type TruthTableResponse struct {
+	Expression     string   `json:"expression"`
+	Variables      []string `json:"variables"`
+	Rows           [][]int  `json:"rows"`
+	Result         []int    `json:"result"`
+	FunctionVector string   `json:"function_vector"`
+}
+
+type NormalFormsRequest struct {
+	Expression string   `json:"expression"`
+	Variables  []string `json:"variables,omitempty"`
+}
+
+type NormalFormsResponse struct {
+	Variables []string `json:"variables"`
+	Minterms  []int    `json:"minterms"`
+	Maxterms  []int    `json:"maxterms"`
+	SDNF      string   `json:"sdnf"`
+	SKNF      string   `json:"sknf"`
+	SDNFLatex string   `json:"sdnf_latex"`
+	SKNFLatex string   `json:"sknf_latex"`
+}
+
+type MinimizationRequest struct {
+	Expression string   `json:"expression,omitempty"`
+	Vector     string   `json:"vector,omitempty"`
+	Minterms   []int    `json:"minterms,omitempty"`
+	DontCares  []int    `json:"dont_cares,omitempty"`
+	Variables  []string `json:"variables,omitempty"`
+}
+
+type Implicant struct {
+	Pattern  string `json:"pattern"`
+	Minterms []int  `json:"minterms"`
+	Term     string `json:"term"`
+}
+
+type MinimizationResponse struct {
+	Variables           []string    `json:"variables"`
+	Minterms            []int       `json:"minterms"`
+	DontCares           []int       `json:"dont_cares"`
+	PrimeImplicants     []Implicant `json:"prime_implicants"`
+	EssentialImplicants []Implicant `json:"essential_implicants"`
+	Chart               [][]int     `json:"chart"`
+	Selected            []Implicant `json:"selected"`
+	Exact               bool        `json:"exact"`
+	MinimalDNF          string      `json:"minimal_dnf"`
+	MinimalCNF          string      `json:"minimal_cnf"`
+}
+
+type KarnaughMapRequest struct {
+	Expression string   `json:"expression"`
+	Variables  []string `json:"variables,omitempty"`
+	Format     string   `json:"format"`
+}
+
+type KarnaughGroup struct {
+	Pattern    string   `json:"pattern"`
+	Term       string   `json:"term"`
+	Color      string   `json:"color"`
+	Cells      [][2]int `json:"cells"`
+	Rectangles [][4]int `json:"rectangles"`
+}
+
+type KarnaughMapResponse struct {
+	Variables       []string        `json:"variables"`
+	RowVariables    []string        `json:"row_variables"`
+	ColumnVariables []string        `json:"column_variables"`
+	RowLabels       []string        `json:"row_labels"`
+	ColumnLabels    []string        `json:"column_labels"`
+	Values          [][]int         `json:"values"`
+	Minterms        [][]int         `json:"minterms"`
+	Groups          []KarnaughGroup `json:"groups"`
+	MinimalDNF      string          `json:"minimal_dnf"`
+}
+
+type BooleanFunctionRequest struct {
+	Expression string   `json:"expression,omitempty"`
+	Vector     string   `json:"vector,omitempty"`
+	Variables  []string `json:"variables,omitempty"`
+}
+
+type ZhegalkinResponse struct {
+	Variables      []string `json:"variables"`
+	FunctionVector string   `json:"function_vector"`
+	Triangle       [][]int  `json:"triangle,omitempty"`
+	Coefficients   []int    `json:"coefficients"`
+	Monomials      []string `json:"monomials"`
+	Polynomial     string   `json:"polynomial"`
+	PolynomialTeX  string   `json:"polynomial_latex"`
+	Degree         int      `json:"degree"`
+	IsLinear       bool     `json:"is_linear"`
+}
+
+type PostClassesRequest struct {
+	Functions []BooleanFunctionRequest `json:"functions"`
+}
+
+type PostClassMembership struct {
+	Function       string   `json:"function"`
+	Variables      []string `json:"variables"`
+	FunctionVector string   `json:"function_vector"`
+	T0             bool     `json:"t0"`
+	T1             bool     `json:"t1"`
+	S              bool     `json:"s"`
+	M              bool     `json:"m"`
+	L              bool     `json:"l"`
+}
+
+// PostClassesResponse lists MinimalSubsystem as 0-based indices into the
+// request's functions, the same indices error messages refer to as functions[i].
+type PostClassesResponse struct {
+	Table             []PostClassMembership `json:"table"`
+	IsComplete        bool                  `json:"is_complete"`
+	ContainingClasses []string              `json:"containing_classes"`
+	MinimalSubsystem  []int                 `json:"minimal_subsystem"`
+}
+
+type BooleanEquivalenceRequest struct {
+	First     string   `json:"first"`
+	Second    string   `json:"second"`
+	Variables []string `json:"variables,omitempty"`
+}
+
+type BooleanEquivalenceResponse struct {
+	Variables                        []string       `json:"variables"`
+	FirstVector                      string         `json:"first_vector"`
+	SecondVector                     string         `json:"second_vector"`
+	Equivalent                       bool           `json:"equivalent"`
+	FirstImpliesSecond               bool           `json:"first_implies_second"`
+	SecondImpliesFirst               bool           `json:"second_implies_first"`
+	EquivalenceCounterexample        map[string]int `json:"equivalence_counterexample,omitempty"`
+	FirstImpliesSecondCounterexample map[string]int `json:"first_implies_second_counterexample,omitempty"`
+	SecondImpliesFirstCounterexample map[string]int `json:"second_implies_first_counterexample,omitempty"`
 }
@@ -0,0 +1,11 @@
+package models
+
+type RelationMatrixResponse struct {
+	Elements        []string `json:"elements"`
+	Matrix          [][]int  `json:"matrix"`
+	IsReflexive     bool     `json:"is_reflexive"`
+	IsIrreflexive   bool     `json:"is_irreflexive"`
+	IsSymmetric     bool     `json:"is_symmetric"`
+	IsAntisymmetric bool     `json:"is_antisymmetric"`
+	IsTransitive    bool     `json:"is_transitive"`
+}
@@ -0,0 +1,8 @@
+package models
+
+type EquivalenceClassesResponse struct {
+	IsEquivalence    bool               `json:"is_equivalence"`
+	FailedProperties []RelationProperty `json:"failed_properties,omitempty"`
+	Classes          [][]string         `json:"classes,omitempty"`
+	Representatives  []string           `json:"representatives,omitempty"`
+}
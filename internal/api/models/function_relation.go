@@ -0,0 +1,17 @@
+package models
+
+type FunctionPropertiesResponse struct {
+	Domain              []string    `json:"domain"`
+	Codomain            []string    `json:"codomain"`
+	IsTotal             bool        `json:"is_total"`
+	IsFunctional        bool        `json:"is_functional"`
+	IsFunction          bool        `json:"is_function"`
+	IsInjective         bool        `json:"is_injective"`
+	IsSurjective        bool        `json:"is_surjective"`
+	IsBijective         bool        `json:"is_bijective"`
+	UnmappedElements    []string    `json:"unmapped_elements"`
+	MultivaluedElements []string    `json:"multivalued_elements"`
+	SharedImages        []string    `json:"shared_images"`
+	UncoveredElements   []string    `json:"uncovered_elements"`
+	Inverse             [][2]string `json:"inverse,omitempty"`
+}
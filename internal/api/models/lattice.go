@@ -0,0 +1,15 @@
+package models
+
+type LatticeResponse struct {
+	Elements        []string            `json:"elements"`
+	JoinTable       [][]*string         `json:"join_table"`
+	MeetTable       [][]*string         `json:"meet_table"`
+	Complements     map[string][]string `json:"complements,omitempty"`
+	IsLattice       bool                `json:"is_lattice"`
+	IsDistributive  bool                `json:"is_distributive"`
+	IsModular       bool                `json:"is_modular"`
+	IsComplemented  bool                `json:"is_complemented"`
+	IsBoolean       bool                `json:"is_boolean"`
+	LeastElement    *string             `json:"least_element,omitempty"`
+	GreatestElement *string             `json:"greatest_element,omitempty"`
+}
@@ -0,0 +1,13 @@
+package models
+
+type PartialOrderResponse struct {
+	IsPartialOrder       bool        `json:"is_partial_order"`
+	IsStrictPartialOrder bool        `json:"is_strict_partial_order"`
+	IsTotalOrder         bool        `json:"is_total_order"`
+	IsStrictTotalOrder   bool        `json:"is_strict_total_order"`
+	MinimalElements      []string    `json:"minimal_elements,omitempty"`
+	MaximalElements      []string    `json:"maximal_elements,omitempty"`
+	LeastElement         *string     `json:"least_element,omitempty"`
+	GreatestElement      *string     `json:"greatest_element,omitempty"`
+	CoverRelation        [][2]string `json:"cover_relation,omitempty"`
+}
@@ -0,0 +1,15 @@
+package models
+
+type RelationOperationRequest struct {
+	Operation    string               `json:"operation"`
+	First        BinaryRelationModel  `json:"first"`
+	Second       *BinaryRelationModel `json:"second,omitempty"`
+	Power        int                  `json:"power"`
+	IncludeGraph bool                 `json:"include_graph"`
+}
+
+type RelationOperationResponse struct {
+	Relation   BinaryRelationModel `json:"relation"`
+	Properties []string            `json:"properties"`
+	GraphImage []byte              `json:"graph_image,omitempty"`
+}
@@ -0,0 +1,14 @@
+package models
+
+type RelationClosure struct {
+	Relation   [][2]string `json:"relation"`
+	AddedPairs [][2]string `json:"added_pairs"`
+	Matrix     [][]int     `json:"matrix"`
+}
+
+type RelationClosureResponse struct {
+	Reflexive   RelationClosure `json:"reflexive"`
+	Symmetric   RelationClosure `json:"symmetric"`
+	Transitive  RelationClosure `json:"transitive"`
+	Equivalence RelationClosure `json:"equivalence"`
+}
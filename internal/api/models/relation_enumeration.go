@@ -0,0 +1,16 @@
+package models
+
+type RelationEnumerationRequest struct {
+	N          int      `json:"n"`
+	Properties []string `json:"properties"`
+	Enumerate  bool     `json:"enumerate"`
+	Limit      int      `json:"limit"`
+}
+
+type RelationEnumerationResponse struct {
+	Elements   []string      `json:"elements"`
+	Properties []string      `json:"properties"`
+	Count      int           `json:"count"`
+	Relations  [][][2]string `json:"relations,omitempty"`
+	Truncated  bool          `json:"truncated"`
+}
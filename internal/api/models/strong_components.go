@@ -0,0 +1,15 @@
+package models
+
+type StrongComponentsRequest struct {
+	BinaryRelationModel
+	IncludeGraph bool       `json:"include_graph"`
+	Style        GraphStyle `json:"style"`
+}
+
+type StrongComponentsResponse struct {
+	Components          [][]string          `json:"components"`
+	ComponentOf         map[string]int      `json:"component_of"`
+	Condensation        BinaryRelationModel `json:"condensation"`
+	IsStronglyConnected bool                `json:"is_strongly_connected"`
+	GraphImage          []byte              `json:"graph_image,omitempty"`
+}
@@ -0,0 +1,13 @@
+package models
+
+type TopologicalSortRequest struct {
+	BinaryRelationModel
+	Limit int `json:"limit"`
+}
+
+type TopologicalSortResponse struct {
+	Order            []string   `json:"order"`
+	LinearExtensions [][]string `json:"linear_extensions"`
+	ExtensionCount   *int64     `json:"extension_count,omitempty"`
+	Truncated        bool       `json:"truncated"`
+}
@@ -4,43 +4,174 @@ import (
 	"bytes"
 	"fmt"
 	"os/exec"
+	"sort"
 
 	"github.com/k6zma/DiscreteSolver/internal/api/models"
+	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/encoding"
 	"gonum.org/v1/gonum/graph/encoding/dot"
-	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/multi"
 )
 
 type BinaryRelationGraph struct {
-	graph    *simple.DirectedGraph
+	*multi.DirectedGraph
 	elements map[string]int64
 	relation [][2]string
+	style    models.GraphStyle
+}
+
+type relationNode struct {
+	id         int64
+	label      string
+	attributes []encoding.Attribute
+}
+
+func (n relationNode) ID() int64 {
+	return n.id
+}
+
+func (n relationNode) Attributes() []encoding.Attribute {
+	if n.attributes != nil {
+		return n.attributes
+	}
+
+	return []encoding.Attribute{
+		{Key: "label", Value: n.label},
+		{Key: "color", Value: "skyblue"},
+		{Key: "style", Value: "filled"},
+	}
+}
+
+type relationLine struct {
+	from       graph.Node
+	to         graph.Node
+	id         int64
+	attributes []encoding.Attribute
+}
+
+func (l relationLine) From() graph.Node {
+	return l.from
+}
+
+func (l relationLine) To() graph.Node {
+	return l.to
+}
+
+func (l relationLine) ReversedLine() graph.Line {
+	return relationLine{from: l.to, to: l.from, id: l.id, attributes: l.attributes}
+}
+
+func (l relationLine) ReversedEdge() graph.Edge {
+	return relationLine{from: l.to, to: l.from, id: l.id, attributes: l.attributes}
+}
+
+func (l relationLine) ID() int64 {
+	return l.id
+}
+
+func (l relationLine) Attributes() []encoding.Attribute {
+	return l.attributes
+}
+
+type dotGraph interface {
+	graph.Directed
+	dot.Attributers
+	MarshalDOT() ([]byte, error)
 }
 
 func NewBinaryRelationGraph(model models.BinaryRelationModel) *BinaryRelationGraph {
-	graph := simple.NewDirectedGraph()
-	elements := make(map[string]int64)
+	return NewStyledBinaryRelationGraph(model, models.GraphStyle{})
+}
 
-	for _, element := range model.SetOfElements {
-		node := graph.NewNode()
-		graph.AddNode(node)
-		elements[element] = node.ID()
+func NewStyledBinaryRelationGraph(model models.BinaryRelationModel, style models.GraphStyle) *BinaryRelationGraph {
+	g := &BinaryRelationGraph{
+		DirectedGraph: multi.NewDirectedGraph(),
+		elements:      make(map[string]int64),
+		relation:      model.BinaryRelation,
+		style:         style,
 	}
 
-	for _, pair := range model.BinaryRelation {
-		from := elements[pair[0]]
-		to := elements[pair[1]]
+	order := getRelationElements(model)
+	for i, element := range order {
+		g.elements[element] = int64(i)
+	}
+
+	for i, element := range order {
+		id := int64(i)
+		g.AddNode(relationNode{id: id, label: element, attributes: g.NodeAttributes(id)})
+	}
 
-		if from != to {
-			graph.SetEdge(graph.NewEdge(graph.Node(from), graph.Node(to)))
+	for i, pair := range sortRelationPairs(order, getRelationSet(model)) {
+		from, fromExists := g.elements[pair[0]]
+		to, toExists := g.elements[pair[1]]
+
+		if fromExists && toExists {
+			line := relationLine{from: g.Node(from), to: g.Node(to), id: int64(i)}
+			line.attributes = g.EdgeAttributes(line)
+			g.SetLine(line)
 		}
 	}
 
-	return &BinaryRelationGraph{
-		graph:    graph,
-		elements: elements,
-		relation: model.BinaryRelation,
+	return g
+}
+
+func duplicateElements(elements []string) []string {
+	duplicates := []string{}
+	seen := make(map[string]int)
+	for _, e := range elements {
+		seen[e]++
+		if seen[e] == 2 {
+			duplicates = append(duplicates, e)
+		}
 	}
+
+	return duplicates
+}
+
+// mergeUnique appends the values of second missing from first, keeping the
+// order in which they appear. Relations use it for element sets and Boolean
+// expressions for their variables.
+func mergeUnique(first, second []string) []string {
+	merged := append([]string{}, first...)
+	seen := make(map[string]struct{})
+	for _, value := range first {
+		seen[value] = struct{}{}
+	}
+
+	for _, value := range second {
+		if _, exists := seen[value]; !exists {
+			seen[value] = struct{}{}
+			merged = append(merged, value)
+		}
+	}
+
+	return merged
+}
+
+func GetUnknownRelationElements(model models.BinaryRelationModel) []string {
+	if len(model.SetOfElements) == 0 {
+		return nil
+	}
+
+	known := make(map[string]struct{})
+	for _, e := range model.SetOfElements {
+		known[e] = struct{}{}
+	}
+
+	unknown := []string{}
+	seen := make(map[string]struct{})
+	for _, pair := range model.BinaryRelation {
+		for _, e := range pair {
+			_, isKnown := known[e]
+			_, isSeen := seen[e]
+			if !isKnown && !isSeen {
+				seen[e] = struct{}{}
+				unknown = append(unknown, e)
+			}
+		}
+	}
+
+	return unknown
 }
 
 func (g *BinaryRelationGraph) DOTID() string {
@@ -48,39 +179,87 @@ func (g *BinaryRelationGraph) DOTID() string {
 }
 
 func (g *BinaryRelationGraph) Attributes() []encoding.Attribute {
-	return []encoding.Attribute{
-		{Key: "label", Value: "Binary Relation Graph"},
-		{Key: "labelloc", Value: "t"},
-	}
+	return styleGraphAttributes(g.style, "Binary Relation Graph", "")
 }
 
 func (g *BinaryRelationGraph) NodeAttributes(id int64) []encoding.Attribute {
 	for key, nodeID := range g.elements {
 		if nodeID == id {
-			return []encoding.Attribute{
-				{Key: "label", Value: key},
-				{Key: "color", Value: "skyblue"},
-				{Key: "style", Value: "filled"},
-			}
+			return styleNodeAttributes(g.style, key)
 		}
 	}
 
 	return nil
 }
 
-func (g *BinaryRelationGraph) EdgeAttributes(e simple.Edge) []encoding.Attribute {
-	return []encoding.Attribute{
-		{Key: "color", Value: "black"},
+func (g *BinaryRelationGraph) EdgeAttributes(l graph.Line) []encoding.Attribute {
+	from, fromOK := l.From().(relationNode)
+	to, toOK := l.To().(relationNode)
+	if !fromOK || !toOK {
+		return styleEdgeAttributes(g.style, [2]string{})
 	}
+
+	return styleEdgeAttributes(g.style, [2]string{from.label, to.label})
 }
 
-func (g *BinaryRelationGraph) GenerateImage() ([]byte, error) {
-	dotData, err := dot.Marshal(g.graph, "BinaryRelationGraph", "", "  ")
+func (g *BinaryRelationGraph) DOTAttributers() (graph, node, edge encoding.Attributer) {
+	graphAttributes := encoding.Attributes(g.Attributes())
+	return &graphAttributes, &encoding.Attributes{}, &encoding.Attributes{}
+}
+
+func (g *BinaryRelationGraph) MarshalDOT() ([]byte, error) {
+	dotData, err := dot.MarshalMulti(g, "", "", "  ")
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal graph to DOT: %v", err)
 	}
 
-	cmd := exec.Command("dot", "-Tpng")
+	return dotData, nil
+}
+
+func (g *BinaryRelationGraph) Render(format, renderer string) ([]byte, error) {
+	layout := g.style.Layout
+	if layout == "" {
+		layout = CircularLayout
+	}
+
+	return renderGraph(g, layout, format, renderer)
+}
+
+func (g *BinaryRelationGraph) GenerateImage(renderer string) ([]byte, error) {
+	return g.Render("png", renderer)
+}
+
+func renderGraph(g dotGraph, layout, format, renderer string) ([]byte, error) {
+	if format == "dot" {
+		return g.MarshalDOT()
+	}
+
+	if err := ValidateRenderer(renderer); err != nil {
+		return nil, err
+	}
+
+	if renderer == GraphvizRenderer {
+		dotData, err := g.MarshalDOT()
+		if err != nil {
+			return nil, err
+		}
+
+		return renderDOT(dotData, format)
+	}
+
+	return NewGraphDrawing(g).Render(layout, format)
+}
+
+func ValidateRenderer(renderer string) error {
+	if renderer != BuiltinRenderer && renderer != GraphvizRenderer {
+		return fmt.Errorf("unknown graph renderer %q, expected %q or %q", renderer, BuiltinRenderer, GraphvizRenderer)
+	}
+
+	return nil
+}
+
+func renderDOT(dotData []byte, format string) ([]byte, error) {
+	cmd := exec.Command("dot", "-T"+format)
 	cmd.Stdin = bytes.NewReader(dotData)
 
 	var out bytes.Buffer
@@ -93,123 +272,201 @@ func (g *BinaryRelationGraph) GenerateImage() ([]byte, error) {
 	return out.Bytes(), nil
 }
 
-func checkReflexiveProperty(elements map[string]struct{}, relation map[[2]string]struct{}) map[string]bool {
-	isReflexive := true
-	isAntireflexive := true
+type RelationProperty struct {
+	Key     string
+	Name    string
+	Holds   bool
+	Witness [][2]string
+	Missing [][2]string
+}
+
+type RelationProperties []RelationProperty
+
+func (p RelationProperties) Holds(key string) bool {
+	for _, property := range p {
+		if property.Key == key {
+			return property.Holds
+		}
+	}
+
+	return false
+}
+
+// The checkers take Holds from BooleanMatrix.HasProperty, which relation
+// enumeration uses as well, and only scan the pairs to find witnesses.
+
+func checkReflexiveProperty(elements []string, relation map[[2]string]struct{}) RelationProperties {
+	matrix := RelationToMatrix(elements, relation)
+
+	reflexive := RelationProperty{Key: "reflexive", Name: "Рефлексивно", Holds: matrix.HasProperty("reflexive")}
+	antireflexive := RelationProperty{Key: "antireflexive", Name: "Антирефлексивно", Holds: matrix.HasProperty("antireflexive")}
+	nonreflexive := RelationProperty{Key: "nonreflexive", Name: "Нерефлексивно", Holds: matrix.HasProperty("nonreflexive")}
 
-	for e := range elements {
+	for _, e := range elements {
 		pair := [2]string{e, e}
 		_, exists := relation[pair]
 
-		if exists {
-			isAntireflexive = false
-		} else {
-			isReflexive = false
+		if !exists && reflexive.Missing == nil {
+			reflexive.Missing = [][2]string{pair}
+		}
+		if exists && antireflexive.Witness == nil {
+			antireflexive.Witness = [][2]string{pair}
 		}
 	}
 
-	isNonreflexive := !isReflexive && !isAntireflexive
-
-	return map[string]bool{
-		"Рефлексивно":     isReflexive,
-		"Антирефлексивно": isAntireflexive,
-		"Нерефлексивно":   isNonreflexive,
+	if nonreflexive.Holds {
+		nonreflexive.Witness = antireflexive.Witness
+		nonreflexive.Missing = reflexive.Missing
 	}
+
+	return RelationProperties{reflexive, antireflexive, nonreflexive}
 }
 
-func checkSymmetryProperties(relation map[[2]string]struct{}) map[string]bool {
-	isSymmetric := true
-	isAsymmetric := true
-	isAntisymmetric := true
+func checkSymmetryProperties(elements []string, relation map[[2]string]struct{}) RelationProperties {
+	matrix := RelationToMatrix(elements, relation)
 
-	for pair := range relation {
+	symmetric := RelationProperty{Key: "symmetric", Name: "Симметрично", Holds: matrix.HasProperty("symmetric")}
+	asymmetric := RelationProperty{Key: "asymmetric", Name: "Асимметрично", Holds: matrix.HasProperty("asymmetric")}
+	antisymmetric := RelationProperty{Key: "antisymmetric", Name: "Антисимметрично", Holds: matrix.HasProperty("antisymmetric")}
+	nonsymmetric := RelationProperty{Key: "nonsymmetric", Name: "Несимметрично", Holds: matrix.HasProperty("nonsymmetric")}
+
+	for _, pair := range sortRelationPairs(elements, relation) {
 		reversePair := [2]string{pair[1], pair[0]}
 		_, exists := relation[reversePair]
 
-		if exists {
-			isAsymmetric = false
-		} else {
-			isSymmetric = false
+		if !exists && symmetric.Witness == nil {
+			symmetric.Witness = [][2]string{pair}
+			symmetric.Missing = [][2]string{reversePair}
 		}
 
-		if pair[0] != pair[1] && exists {
-			isAntisymmetric = false
+		if exists && asymmetric.Witness == nil {
+			asymmetric.Witness = [][2]string{pair}
+			if pair[0] != pair[1] {
+				asymmetric.Witness = append(asymmetric.Witness, reversePair)
+			}
 		}
-	}
 
-	isNonsymmetric := !isSymmetric && !isAntisymmetric
+		if pair[0] != pair[1] && exists && antisymmetric.Witness == nil {
+			antisymmetric.Witness = [][2]string{pair, reversePair}
+		}
+	}
 
-	return map[string]bool{
-		"Симметрично":     isSymmetric,
-		"Асимметрично":    isAsymmetric,
-		"Антисимметрично": isAntisymmetric,
-		"Несимметрично":   isNonsymmetric,
+	if nonsymmetric.Holds {
+		nonsymmetric.Witness = append(append([][2]string{}, symmetric.Witness...), antisymmetric.Witness...)
+		nonsymmetric.Missing = symmetric.Missing
 	}
+
+	return RelationProperties{symmetric, asymmetric, antisymmetric, nonsymmetric}
 }
 
-func checkTransitivityProperties(relation map[[2]string]struct{}) map[string]bool {
-	isTransitive := true
-	isAntitransitive := true
+func checkTransitivityProperties(elements []string, relation map[[2]string]struct{}) RelationProperties {
+	matrix := RelationToMatrix(elements, relation)
+
+	transitive := RelationProperty{Key: "transitive", Name: "Транзитивно", Holds: matrix.HasProperty("transitive")}
+	antitransitive := RelationProperty{Key: "antitransitive", Name: "Антитранзитивно", Holds: matrix.HasProperty("antitransitive")}
+	nontransitive := RelationProperty{Key: "nontransitive", Name: "Нетранзитивно", Holds: matrix.HasProperty("nontransitive")}
 
-	for pair1 := range relation {
-		for pair2 := range relation {
-			if pair1[1] == pair2[0] {
-				newPair := [2]string{pair1[0], pair2[1]}
-				_, exists := relation[newPair]
-				if !exists {
-					isTransitive = false
-				} else {
-					isAntitransitive = false
-				}
+	pairs := sortRelationPairs(elements, relation)
+	for _, pair1 := range pairs {
+		for _, pair2 := range pairs {
+			if pair1[1] != pair2[0] {
+				continue
+			}
+
+			newPair := [2]string{pair1[0], pair2[1]}
+			_, exists := relation[newPair]
+
+			if !exists && transitive.Witness == nil {
+				transitive.Witness = [][2]string{pair1, pair2}
+				transitive.Missing = [][2]string{newPair}
+			}
+
+			if exists && antitransitive.Witness == nil {
+				antitransitive.Witness = [][2]string{pair1, pair2, newPair}
 			}
 		}
 	}
 
-	isNontransitive := !isTransitive && !isAntitransitive
-
-	return map[string]bool{
-		"Транзитивно":     isTransitive,
-		"Антитранзитивно": isAntitransitive,
-		"Нетранзитивно":   isNontransitive,
+	if nontransitive.Holds {
+		nontransitive.Witness = append(append([][2]string{}, transitive.Witness...), antitransitive.Witness...)
+		nontransitive.Missing = transitive.Missing
 	}
+
+	return RelationProperties{transitive, antitransitive, nontransitive}
 }
 
-func GetRelationProperties(model models.BinaryRelationModel) []string {
-	elements := make(map[string]struct{})
+func getRelationElements(model models.BinaryRelationModel) []string {
+	elements := []string{}
+	seen := make(map[string]struct{})
+
+	addElement := func(element string) {
+		if _, exists := seen[element]; !exists {
+			seen[element] = struct{}{}
+			elements = append(elements, element)
+		}
+	}
+
 	for _, e := range model.SetOfElements {
-		elements[e] = struct{}{}
+		addElement(e)
 	}
 
 	if len(elements) == 0 {
 		for _, pair := range model.BinaryRelation {
-			elements[pair[0]] = struct{}{}
-			elements[pair[1]] = struct{}{}
+			addElement(pair[0])
+			addElement(pair[1])
 		}
 	}
 
+	return elements
+}
+
+func getRelationSet(model models.BinaryRelationModel) map[[2]string]struct{} {
 	relation := make(map[[2]string]struct{})
 	for _, pair := range model.BinaryRelation {
 		relation[[2]string{pair[0], pair[1]}] = struct{}{}
 	}
 
-	reflexiveProperties := checkReflexiveProperty(elements, relation)
-	symmetryProperties := checkSymmetryProperties(relation)
-	transitivityProperties := checkTransitivityProperties(relation)
+	return relation
+}
 
-	propertiesList := []string{}
-	for property, isTrue := range reflexiveProperties {
-		if isTrue {
-			propertiesList = append(propertiesList, property)
-		}
+func sortRelationPairs(elements []string, relation map[[2]string]struct{}) [][2]string {
+	indices := make(map[string]int)
+	for i, e := range elements {
+		indices[e] = i
 	}
-	for property, isTrue := range symmetryProperties {
-		if isTrue {
-			propertiesList = append(propertiesList, property)
-		}
+
+	pairs := make([][2]string, 0, len(relation))
+	for pair := range relation {
+		pairs = append(pairs, pair)
 	}
-	for property, isTrue := range transitivityProperties {
-		if isTrue {
-			propertiesList = append(propertiesList, property)
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if indices[pairs[i][0]] != indices[pairs[j][0]] {
+			return indices[pairs[i][0]] < indices[pairs[j][0]]
+		}
+		return indices[pairs[i][1]] < indices[pairs[j][1]]
+	})
+
+	return pairs
+}
+
+func GetRelationPropertiesReport(model models.BinaryRelationModel) RelationProperties {
+	elements := getRelationElements(model)
+	relation := getRelationSet(model)
+
+	report := RelationProperties{}
+	report = append(report, checkReflexiveProperty(elements, relation)...)
+	report = append(report, checkSymmetryProperties(elements, relation)...)
+	report = append(report, checkTransitivityProperties(elements, relation)...)
+
+	return report
+}
+
+func GetRelationProperties(model models.BinaryRelationModel) []string {
+	propertiesList := []string{}
+	for _, property := range GetRelationPropertiesReport(model) {
+		if property.Holds {
+			propertiesList = append(propertiesList, property.Name)
 		}
 	}
 
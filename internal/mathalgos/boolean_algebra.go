@@ -2,14 +2,18 @@ package mathalgos
 
 import (
 	"bytes"
+	"encoding/csv"
 	"fmt"
 	"image"
 	"image/color"
 	"image/draw"
 	"image/png"
+	"math/bits"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
-	"github.com/Knetic/govaluate"
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/basicfont"
 	"golang.org/x/image/math/fixed"
@@ -21,31 +25,65 @@ func NewLogicSimplifier() *LogicSimplifier {
 	return &LogicSimplifier{}
 }
 
-func (s *LogicSimplifier) ExtractVariables(exprStr string) map[string]bool {
-	variables := make(map[string]bool)
-	for _, char := range exprStr {
-		if (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') {
-			variables[string(char)] = true
-		}
+func (s *LogicSimplifier) Parse(exprStr string) (BooleanExpression, error) {
+	return ParseBooleanExpression(exprStr)
+}
+
+// ExtractVariables returns the variables of the expression in natural order,
+// so x2 comes before x10.
+func (s *LogicSimplifier) ExtractVariables(exprStr string) ([]string, error) {
+	expr, err := s.Parse(exprStr)
+	if err != nil {
+		return nil, err
 	}
-	return variables
+
+	variables := expr.Variables()
+	sort.Slice(variables, func(i, j int) bool {
+		return naturalLess(variables[i], variables[j])
+	})
+	return variables, nil
 }
 
-func (s *LogicSimplifier) TransformExpression(exprStr string) string {
-	replacements := map[string]string{
-		"∧": "&&",
-		"∨": "||",
-		"⊕": "^",
-		"¬": "!",
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		aDigits, bDigits := leadingDigits(a), leadingDigits(b)
+
+		switch {
+		case aDigits != "" && bDigits != "":
+			aNumber, bNumber := strings.TrimLeft(aDigits, "0"), strings.TrimLeft(bDigits, "0")
+			if len(aNumber) != len(bNumber) {
+				return len(aNumber) < len(bNumber)
+			}
+			if aNumber != bNumber {
+				return aNumber < bNumber
+			}
+			if len(aDigits) != len(bDigits) {
+				return len(aDigits) < len(bDigits)
+			}
+			a, b = a[len(aDigits):], b[len(bDigits):]
+		case a[0] != b[0]:
+			return a[0] < b[0]
+		default:
+			a, b = a[1:], b[1:]
+		}
 	}
-	for old, new := range replacements {
-		exprStr = strings.ReplaceAll(exprStr, old, new)
+
+	return len(a) < len(b)
+}
+
+func leadingDigits(s string) string {
+	end := 0
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
 	}
-	return exprStr
+	return s[:end]
 }
 
+const maxTruthTableVariables = 16
+
 type TruthTableGenerator struct {
 	expression string
+	variables  []string
 	simplifier *LogicSimplifier
 }
 
@@ -56,35 +94,192 @@ func NewTruthTableGenerator(expression string) *TruthTableGenerator {
 	}
 }
 
-func (t *TruthTableGenerator) GenerateTruthTable() ([][]bool, []string, error) {
-	variables := t.simplifier.ExtractVariables(t.expression)
-	varNames := make([]string, 0, len(variables))
-	for variable := range variables {
-		varNames = append(varNames, variable)
+// NewOrderedTruthTableGenerator fixes the column order. The order must list
+// every variable of the expression and may add variables it does not use.
+func NewOrderedTruthTableGenerator(expression string, variables []string) (*TruthTableGenerator, error) {
+	generator := NewTruthTableGenerator(expression)
+	if len(variables) == 0 {
+		return generator, nil
 	}
 
-	rows := generateCombinations(len(variables))
-	results := make([][]bool, len(rows))
+	used, err := generator.simplifier.ExtractVariables(expression)
+	if err != nil {
+		return nil, err
+	}
 
-	exprStr := t.simplifier.TransformExpression(t.expression)
-	expr, err := govaluate.NewEvaluableExpression(exprStr)
+	seen := make(map[string]struct{})
+	for _, variable := range variables {
+		if _, exists := seen[variable]; exists {
+			return nil, fmt.Errorf("variable %q is listed more than once", variable)
+		}
+		parsed, err := ParseBooleanExpression(variable)
+		if _, isVariable := parsed.(booleanVariable); err != nil || !isVariable {
+			return nil, fmt.Errorf("invalid variable name: %q", variable)
+		}
+		seen[variable] = struct{}{}
+	}
+
+	missing := []string{}
+	for _, variable := range used {
+		if _, exists := seen[variable]; !exists {
+			missing = append(missing, variable)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("variable order is missing: %s", strings.Join(missing, ", "))
+	}
+
+	generator.variables = variables
+	return generator, nil
+}
+
+func (t *TruthTableGenerator) GenerateTruthTable() ([][]bool, []string, error) {
+	expr, err := t.simplifier.Parse(t.expression)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error parsing expression: %v", err)
+		return nil, nil, err
+	}
+
+	varNames := t.variables
+	if varNames == nil {
+		varNames, err = t.simplifier.ExtractVariables(t.expression)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(varNames) > maxTruthTableVariables {
+		return nil, nil, fmt.Errorf("truth tables support at most %d variables, got %d", maxTruthTableVariables, len(varNames))
 	}
 
+	rows := generateCombinations(len(varNames))
+	results := make([][]bool, len(rows))
+
 	for i, row := range rows {
-		parameters := make(map[string]interface{})
+		parameters := make(map[string]bool)
 		for j, variable := range varNames {
 			parameters[variable] = row[j]
 		}
-		result, err := expr.Evaluate(parameters)
+		results[i] = append(row, expr.Evaluate(parameters))
+	}
+
+	return results, varNames, nil
+}
+
+type TruthTable struct {
+	Expression     string
+	Variables      []string
+	Rows           [][]int
+	Result         []int
+	FunctionVector string
+}
+
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+func (t *TruthTableGenerator) BuildTruthTable() (TruthTable, error) {
+	truthTable, varNames, err := t.GenerateTruthTable()
+	if err != nil {
+		return TruthTable{}, err
+	}
+
+	table := TruthTable{
+		Expression: t.expression,
+		Variables:  varNames,
+		Rows:       make([][]int, len(truthTable)),
+		Result:     make([]int, len(truthTable)),
+	}
+
+	vector := strings.Builder{}
+	for i, row := range truthTable {
+		table.Rows[i] = make([]int, len(varNames))
+		for j := range varNames {
+			table.Rows[i][j] = boolToInt(row[j])
+		}
+		table.Result[i] = boolToInt(row[len(varNames)])
+		vector.WriteString(strconv.Itoa(table.Result[i]))
+	}
+	table.FunctionVector = vector.String()
+
+	return table, nil
+}
+
+// FunctionValues returns the variables and the function vector of a Boolean
+// function given either as an expression or as a 0/1 vector of length 2^n.
+func FunctionValues(expression, vector string, variables []string) ([]string, []int, error) {
+	if (expression == "") == (vector == "") {
+		return nil, nil, fmt.Errorf("exactly one of expression or vector must be provided")
+	}
+
+	if expression != "" {
+		generator, err := NewOrderedTruthTableGenerator(expression, variables)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error evaluating expression: %v", err)
+			return nil, nil, err
 		}
-		results[i] = append(row, result.(bool))
+		table, err := generator.BuildTruthTable()
+		if err != nil {
+			return nil, nil, err
+		}
+		return table.Variables, table.Result, nil
 	}
 
-	return results, varNames, nil
+	n := bits.Len(uint(len(vector))) - 1
+	if 1<<n != len(vector) {
+		return nil, nil, fmt.Errorf("function vector length must be a power of two, got %d", len(vector))
+	}
+	if n > maxTruthTableVariables {
+		return nil, nil, fmt.Errorf("truth tables support at most %d variables, got %d", maxTruthTableVariables, n)
+	}
+
+	if len(variables) == 0 {
+		variables = defaultVariables(n)
+	}
+	if len(variables) != n {
+		return nil, nil, fmt.Errorf("function vector of length %d needs %d variables, got %d", len(vector), n, len(variables))
+	}
+
+	values := make([]int, len(vector))
+	for i, value := range vector {
+		switch value {
+		case '0':
+		case '1':
+			values[i] = 1
+		default:
+			return nil, nil, fmt.Errorf("function vector may only contain 0 and 1, got %q at position %d", value, i+1)
+		}
+	}
+
+	return variables, values, nil
+}
+
+func (t TruthTable) CSV() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer := csv.NewWriter(buffer)
+
+	if err := writer.Write(append(append([]string{}, t.Variables...), t.Expression)); err != nil {
+		return nil, fmt.Errorf("error writing CSV: %v", err)
+	}
+
+	for i, row := range t.Rows {
+		record := make([]string, 0, len(row)+1)
+		for _, value := range row {
+			record = append(record, strconv.Itoa(value))
+		}
+		record = append(record, strconv.Itoa(t.Result[i]))
+		if err := writer.Write(record); err != nil {
+			return nil, fmt.Errorf("error writing CSV: %v", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("error writing CSV: %v", err)
+	}
+
+	return buffer.Bytes(), nil
 }
 
 func (t *TruthTableGenerator) CreateTruthTableImage() ([]byte, error) {
@@ -93,8 +288,17 @@ func (t *TruthTableGenerator) CreateTruthTableImage() ([]byte, error) {
 		return nil, err
 	}
 
-	width := (len(varNames) + 1) * 100
+	// The row number and each variable take 100 pixels; the result column is
+	// as wide as the expression header, whose glyphs are 7 pixels wide.
+	resultWidth := 100
+	if headerWidth := 7*utf8.RuneCountInString(t.expression) + 20; headerWidth > resultWidth {
+		resultWidth = headerWidth
+	}
+	width := (len(varNames)+1)*100 + resultWidth
 	height := (len(truthTable) + 1) * 50
+	if width*height > maxImagePixels {
+		return nil, fmt.Errorf("truth table image of %dx%d pixels exceeds the limit of %d pixels, request json or csv instead", width, height, maxImagePixels)
+	}
 
 	img := image.NewRGBA(image.Rect(0, 0, width, height))
 	white := color.RGBA{255, 255, 255, 255}
@@ -133,7 +337,7 @@ func generateCombinations(n int) [][]bool {
 	for i := 0; i < (1 << n); i++ {
 		combination := make([]bool, n)
 		for j := 0; j < n; j++ {
-			combination[j] = (i>>j)&1 == 1
+			combination[j] = (i>>(n-1-j))&1 == 1
 		}
 		combinations = append(combinations, combination)
 	}
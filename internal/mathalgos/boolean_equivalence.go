@@ -0,0 +1,92 @@
+package mathalgos
+
+import (
+	"fmt"
+	"sort"
+)
+
+type BooleanComparison struct {
+	Variables                        []string
+	FirstVector                      string
+	SecondVector                     string
+	Equivalent                       bool
+	FirstImpliesSecond               bool
+	SecondImpliesFirst               bool
+	EquivalenceCounterexample        map[string]int
+	FirstImpliesSecondCounterexample map[string]int
+	SecondImpliesFirstCounterexample map[string]int
+}
+
+func assignment(variables []string, row []int) map[string]int {
+	values := make(map[string]int, len(variables))
+	for i, variable := range variables {
+		values[variable] = row[i]
+	}
+	return values
+}
+
+// CompareBooleanExpressions evaluates both formulas over the union of their
+// variables, so a variable missing from one side is simply ignored there.
+func CompareBooleanExpressions(first, second string, variables []string) (BooleanComparison, error) {
+	simplifier := NewLogicSimplifier()
+
+	if len(variables) == 0 {
+		firstVariables, err := simplifier.ExtractVariables(first)
+		if err != nil {
+			return BooleanComparison{}, fmt.Errorf("first expression: %w", err)
+		}
+		secondVariables, err := simplifier.ExtractVariables(second)
+		if err != nil {
+			return BooleanComparison{}, fmt.Errorf("second expression: %w", err)
+		}
+
+		variables = mergeUnique(firstVariables, secondVariables)
+		sort.Slice(variables, func(i, j int) bool {
+			return naturalLess(variables[i], variables[j])
+		})
+	}
+
+	labels := []string{"first", "second"}
+	tables := make([]TruthTable, 2)
+	for i, expression := range []string{first, second} {
+		generator, err := NewOrderedTruthTableGenerator(expression, variables)
+		if err != nil {
+			return BooleanComparison{}, fmt.Errorf("%s expression: %w", labels[i], err)
+		}
+		tables[i], err = generator.BuildTruthTable()
+		if err != nil {
+			return BooleanComparison{}, fmt.Errorf("%s expression: %w", labels[i], err)
+		}
+	}
+
+	comparison := BooleanComparison{
+		Variables:          tables[0].Variables,
+		FirstVector:        tables[0].FunctionVector,
+		SecondVector:       tables[1].FunctionVector,
+		Equivalent:         true,
+		FirstImpliesSecond: true,
+		SecondImpliesFirst: true,
+	}
+
+	for i, row := range tables[0].Rows {
+		a, b := tables[0].Result[i], tables[1].Result[i]
+		if a == b {
+			continue
+		}
+
+		if comparison.Equivalent {
+			comparison.Equivalent = false
+			comparison.EquivalenceCounterexample = assignment(comparison.Variables, row)
+		}
+		if a == 1 && comparison.FirstImpliesSecond {
+			comparison.FirstImpliesSecond = false
+			comparison.FirstImpliesSecondCounterexample = assignment(comparison.Variables, row)
+		}
+		if b == 1 && comparison.SecondImpliesFirst {
+			comparison.SecondImpliesFirst = false
+			comparison.SecondImpliesFirstCounterexample = assignment(comparison.Variables, row)
+		}
+	}
+
+	return comparison, nil
+}
@@ -0,0 +1,409 @@
+package mathalgos
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+)
+
+type BooleanMatrix [][]bool
+
+type RelationMatrixAnalysis struct {
+	Elements        []string
+	Matrix          [][]int
+	IsReflexive     bool
+	IsIrreflexive   bool
+	IsSymmetric     bool
+	IsAntisymmetric bool
+	IsTransitive    bool
+}
+
+func NewBooleanMatrix(n int) BooleanMatrix {
+	matrix := make(BooleanMatrix, n)
+	for i := range matrix {
+		matrix[i] = make([]bool, n)
+	}
+
+	return matrix
+}
+
+func IdentityMatrix(n int) BooleanMatrix {
+	matrix := NewBooleanMatrix(n)
+	for i := range matrix {
+		matrix[i][i] = true
+	}
+
+	return matrix
+}
+
+func RelationToMatrix(elements []string, relation map[[2]string]struct{}) BooleanMatrix {
+	indices := make(map[string]int)
+	for i, e := range elements {
+		indices[e] = i
+	}
+
+	matrix := NewBooleanMatrix(len(elements))
+	for pair := range relation {
+		from, fromExists := indices[pair[0]]
+		to, toExists := indices[pair[1]]
+		if fromExists && toExists {
+			matrix[from][to] = true
+		}
+	}
+
+	return matrix
+}
+
+func (m BooleanMatrix) ToRelation(elements []string) map[[2]string]struct{} {
+	relation := make(map[[2]string]struct{})
+	for i := range m {
+		for j := range m[i] {
+			if m[i][j] {
+				relation[[2]string{elements[i], elements[j]}] = struct{}{}
+			}
+		}
+	}
+
+	return relation
+}
+
+func (m BooleanMatrix) Ints() [][]int {
+	result := make([][]int, len(m))
+	for i := range m {
+		result[i] = make([]int, len(m[i]))
+		for j := range m[i] {
+			if m[i][j] {
+				result[i][j] = 1
+			}
+		}
+	}
+
+	return result
+}
+
+func (m BooleanMatrix) Copy() BooleanMatrix {
+	result := NewBooleanMatrix(len(m))
+	for i := range m {
+		copy(result[i], m[i])
+	}
+
+	return result
+}
+
+func (m BooleanMatrix) Or(other BooleanMatrix) BooleanMatrix {
+	result := NewBooleanMatrix(len(m))
+	for i := range m {
+		for j := range m[i] {
+			result[i][j] = m[i][j] || other[i][j]
+		}
+	}
+
+	return result
+}
+
+func (m BooleanMatrix) And(other BooleanMatrix) BooleanMatrix {
+	result := NewBooleanMatrix(len(m))
+	for i := range m {
+		for j := range m[i] {
+			result[i][j] = m[i][j] && other[i][j]
+		}
+	}
+
+	return result
+}
+
+func (m BooleanMatrix) Not() BooleanMatrix {
+	result := NewBooleanMatrix(len(m))
+	for i := range m {
+		for j := range m[i] {
+			result[i][j] = !m[i][j]
+		}
+	}
+
+	return result
+}
+
+func (m BooleanMatrix) Transpose() BooleanMatrix {
+	result := NewBooleanMatrix(len(m))
+	for i := range m {
+		for j := range m[i] {
+			result[j][i] = m[i][j]
+		}
+	}
+
+	return result
+}
+
+func (m BooleanMatrix) Multiply(other BooleanMatrix) BooleanMatrix {
+	result := NewBooleanMatrix(len(m))
+	for i := range m {
+		for k := range m[i] {
+			if !m[i][k] {
+				continue
+			}
+			for j := range other[k] {
+				if other[k][j] {
+					result[i][j] = true
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// Power raises the matrix to the n-th power by repeated squaring, so large
+// exponents cost O(log n) multiplications.
+func (m BooleanMatrix) Power(n int) BooleanMatrix {
+	result := IdentityMatrix(len(m))
+	base := m.Copy()
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result = result.Multiply(base)
+		}
+		base = base.Multiply(base)
+	}
+
+	return result
+}
+
+func (m BooleanMatrix) Warshall() BooleanMatrix {
+	result := m.Copy()
+	for k := range result {
+		for i := range result {
+			if !result[i][k] {
+				continue
+			}
+			for j := range result {
+				if result[k][j] {
+					result[i][j] = true
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+func (m BooleanMatrix) Contains(other BooleanMatrix) bool {
+	for i := range m {
+		for j := range m[i] {
+			if other[i][j] && !m[i][j] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (m BooleanMatrix) Equals(other BooleanMatrix) bool {
+	return m.Contains(other) && other.Contains(m)
+}
+
+func (m BooleanMatrix) IsReflexive() bool {
+	return m.Contains(IdentityMatrix(len(m)))
+}
+
+func (m BooleanMatrix) IsIrreflexive() bool {
+	return m.And(IdentityMatrix(len(m))).Equals(NewBooleanMatrix(len(m)))
+}
+
+func (m BooleanMatrix) IsSymmetric() bool {
+	return m.Equals(m.Transpose())
+}
+
+func (m BooleanMatrix) IsAntisymmetric() bool {
+	return IdentityMatrix(len(m)).Contains(m.And(m.Transpose()))
+}
+
+func (m BooleanMatrix) IsAsymmetric() bool {
+	return m.And(m.Transpose()).Equals(NewBooleanMatrix(len(m)))
+}
+
+func (m BooleanMatrix) IsTransitive() bool {
+	return m.Contains(m.Multiply(m))
+}
+
+func (m BooleanMatrix) IsAntitransitive() bool {
+	return m.And(m.Multiply(m)).Equals(NewBooleanMatrix(len(m)))
+}
+
+// HasProperty reports whether the relation has the property with the given
+// key. A "non" property holds when neither the property nor its "anti" form
+// does, so nonsymmetric excludes both symmetric and antisymmetric relations.
+func (m BooleanMatrix) HasProperty(key string) bool {
+	switch key {
+	case "reflexive":
+		return m.IsReflexive()
+	case "antireflexive":
+		return m.IsIrreflexive()
+	case "nonreflexive":
+		return !m.IsReflexive() && !m.IsIrreflexive()
+	case "symmetric":
+		return m.IsSymmetric()
+	case "asymmetric":
+		return m.IsAsymmetric()
+	case "antisymmetric":
+		return m.IsAntisymmetric()
+	case "nonsymmetric":
+		return !m.IsSymmetric() && !m.IsAntisymmetric()
+	case "transitive":
+		return m.IsTransitive()
+	case "antitransitive":
+		return m.IsAntitransitive()
+	case "nontransitive":
+		return !m.IsTransitive() && !m.IsAntitransitive()
+	}
+
+	return false
+}
+
+func MatrixToRelationModel(elements []string, matrix [][]int) (models.BinaryRelationModel, error) {
+	relation, err := matrixPairs(elements, elements, matrix)
+	if err != nil {
+		return models.BinaryRelationModel{}, err
+	}
+
+	return models.BinaryRelationModel{
+		SetOfElements:  elements,
+		BinaryRelation: relation,
+		Matrix:         matrix,
+	}, nil
+}
+
+// matrixPairs reads a rows × columns adjacency matrix, where matrix[i][j] = 1
+// relates rows[i] to columns[j].
+func matrixPairs(rows, columns []string, matrix [][]int) ([][2]string, error) {
+	if len(matrix) != len(rows) {
+		return nil, fmt.Errorf("matrix has %d rows, expected %d", len(matrix), len(rows))
+	}
+
+	relation := [][2]string{}
+	for i, row := range matrix {
+		if len(row) != len(columns) {
+			return nil, fmt.Errorf("matrix row %d has %d columns, expected %d", i, len(row), len(columns))
+		}
+
+		for j, value := range row {
+			switch value {
+			case 0:
+			case 1:
+				relation = append(relation, [2]string{rows[i], columns[j]})
+			default:
+				return nil, fmt.Errorf("matrix value at (%d, %d) must be 0 or 1, got %d", i, j, value)
+			}
+		}
+	}
+
+	return relation, nil
+}
+
+func NormalizeRelationModel(model models.BinaryRelationModel) (models.BinaryRelationModel, error) {
+	if model.Predicate != "" {
+		materialized, err := MaterializePredicateRelation(model)
+		if err != nil {
+			return models.BinaryRelationModel{}, err
+		}
+		model = materialized
+	}
+
+	// Matrix rows are matched to elements by position, so a repeated element
+	// is rejected rather than merged on both input paths.
+	if duplicates := duplicateElements(model.SetOfElements); len(duplicates) > 0 {
+		return models.BinaryRelationModel{}, fmt.Errorf("duplicate elements in set_of_elements: %s", strings.Join(duplicates, ", "))
+	}
+
+	if len(model.SetOfElements) == 0 {
+		model.SetOfElements = mergeUnique(model.Domain, model.Codomain)
+	}
+
+	if unknown := GetUnknownRelationElements(model); len(unknown) > 0 {
+		return models.BinaryRelationModel{}, fmt.Errorf("unknown elements in binary_relation: %s", strings.Join(unknown, ", "))
+	}
+
+	if len(model.Matrix) > 0 {
+		if len(model.SetOfElements) == 0 {
+			return models.BinaryRelationModel{}, fmt.Errorf("set_of_elements is required when matrix is provided")
+		}
+
+		fromMatrix, err := MatrixToRelationModel(model.SetOfElements, model.Matrix)
+		if err != nil {
+			return models.BinaryRelationModel{}, err
+		}
+
+		if len(model.BinaryRelation) > 0 {
+			matrix := RelationToMatrix(model.SetOfElements, getRelationSet(model))
+			if !matrix.Equals(RelationToMatrix(model.SetOfElements, getRelationSet(fromMatrix))) {
+				return models.BinaryRelationModel{}, fmt.Errorf("binary_relation and matrix describe different relations")
+			}
+		}
+
+		model.BinaryRelation = fromMatrix.BinaryRelation
+		return model, nil
+	}
+
+	model.SetOfElements = getRelationElements(model)
+	model.Matrix = RelationToMatrix(model.SetOfElements, getRelationSet(model)).Ints()
+
+	return model, nil
+}
+
+func AnalyzeRelationMatrix(model models.BinaryRelationModel) RelationMatrixAnalysis {
+	elements := getRelationElements(model)
+	matrix := RelationToMatrix(elements, getRelationSet(model))
+
+	return RelationMatrixAnalysis{
+		Elements:        elements,
+		Matrix:          matrix.Ints(),
+		IsReflexive:     matrix.IsReflexive(),
+		IsIrreflexive:   matrix.IsIrreflexive(),
+		IsSymmetric:     matrix.IsSymmetric(),
+		IsAntisymmetric: matrix.IsAntisymmetric(),
+		IsTransitive:    matrix.IsTransitive(),
+	}
+}
+
+func CreateRelationMatrixImage(model models.BinaryRelationModel) ([]byte, error) {
+	elements := getRelationElements(model)
+	matrix := RelationToMatrix(elements, getRelationSet(model))
+
+	width := (len(elements) + 1) * 50
+	height := (len(elements) + 1) * 50
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	white := color.RGBA{255, 255, 255, 255}
+	black := color.RGBA{0, 0, 0, 255}
+
+	draw.Draw(img, img.Bounds(), &image.Uniform{white}, image.Point{}, draw.Src)
+
+	drawString(img, 10, 30, "R", black)
+	for i, element := range elements {
+		drawString(img, 50*(i+1)+10, 30, element, black)
+		drawString(img, 10, 50*(i+1)+30, element, black)
+	}
+
+	for i, row := range matrix {
+		for j, val := range row {
+			valStr := "0"
+			if val {
+				valStr = "1"
+			}
+			drawString(img, 50*(j+1)+10, 50*(i+1)+30, valStr, black)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	err := png.Encode(buffer, img)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding image to PNG: %v", err)
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,714 @@
+package mathalgos
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"sort"
+	"strings"
+)
+
+const (
+	maxMinimizationVariables = 10
+	maxPetrickSteps          = 1 << 17
+)
+
+type implicant struct {
+	value int
+	mask  int
+}
+
+type Implicant struct {
+	Pattern  string
+	Minterms []int
+	Term     string
+}
+
+type Minimization struct {
+	Variables           []string
+	Minterms            []int
+	DontCares           []int
+	PrimeImplicants     []Implicant
+	EssentialImplicants []Implicant
+	Chart               [][]int
+	Selected            []Implicant
+	Exact               bool
+	MinimalDNF          string
+	MinimalCNF          string
+}
+
+func (imp implicant) covers(minterm int) bool {
+	return minterm&^imp.mask == imp.value
+}
+
+func (imp implicant) literals(n int) int {
+	return n - bits.OnesCount(uint(imp.mask))
+}
+
+func (imp implicant) pattern(n int) string {
+	pattern := make([]byte, n)
+	for j := 0; j < n; j++ {
+		bit := 1 << (n - 1 - j)
+		switch {
+		case imp.mask&bit != 0:
+			pattern[j] = '-'
+		case imp.value&bit != 0:
+			pattern[j] = '1'
+		default:
+			pattern[j] = '0'
+		}
+	}
+	return string(pattern)
+}
+
+// term formats the implicant as a conjunction, or as a disjunction of negated
+// literals when it is an implicant of the complement used for the CNF.
+func (imp implicant) term(variables []string, clause bool) string {
+	n := len(variables)
+	literals := []string{}
+	for j, variable := range variables {
+		bit := 1 << (n - 1 - j)
+		if imp.mask&bit != 0 {
+			continue
+		}
+		if (imp.value&bit != 0) == clause {
+			literals = append(literals, "¬"+variable)
+		} else {
+			literals = append(literals, variable)
+		}
+	}
+
+	if len(literals) == 0 {
+		if clause {
+			return "0"
+		}
+		return "1"
+	}
+	if clause {
+		return strings.Join(literals, " ∨ ")
+	}
+	return strings.Join(literals, " ∧ ")
+}
+
+func primeImplicants(n int, terms []int) []implicant {
+	current := make(map[implicant]struct{})
+	for _, t := range terms {
+		current[implicant{value: t}] = struct{}{}
+	}
+
+	primes := []implicant{}
+	for len(current) > 0 {
+		next := make(map[implicant]struct{})
+		combined := make(map[implicant]bool)
+
+		list := make([]implicant, 0, len(current))
+		for imp := range current {
+			list = append(list, imp)
+		}
+
+		for i := 0; i < len(list); i++ {
+			for j := i + 1; j < len(list); j++ {
+				a, b := list[i], list[j]
+				diff := a.value ^ b.value
+				if a.mask != b.mask || bits.OnesCount(uint(diff)) != 1 {
+					continue
+				}
+				next[implicant{value: a.value &^ diff, mask: a.mask | diff}] = struct{}{}
+				combined[a], combined[b] = true, true
+			}
+		}
+
+		for _, imp := range list {
+			if !combined[imp] {
+				primes = append(primes, imp)
+			}
+		}
+		current = next
+	}
+
+	sort.Slice(primes, func(i, j int) bool {
+		if primes[i].literals(n) != primes[j].literals(n) {
+			return primes[i].literals(n) < primes[j].literals(n)
+		}
+		return primes[i].pattern(n) > primes[j].pattern(n)
+	})
+
+	return primes
+}
+
+// petrickSearch walks Petrick's product of sums depth first instead of
+// expanding it. Each step branches on the primes covering the uncovered
+// minterm with the fewest options. A prime already tried for that minterm is
+// excluded from the later branches, since every cover using it was reached in
+// its own branch, and a branch is cut once its cost plus a lower bound for the
+// minterms left uncovered can no longer beat the best cover found so far.
+type petrickSearch struct {
+	literals     []int
+	coveredBy    [][]int
+	covers       [][]int
+	coverage     []int
+	excluded     []bool
+	marks        []int
+	mark         int
+	selected     []int
+	count        int
+	literalCount int
+	best         []int
+	bestCount    int
+	bestLiterals int
+	steps        int
+}
+
+func (s *petrickSearch) improves(count, literals int) bool {
+	return count < s.bestCount || (count == s.bestCount && literals < s.bestLiterals)
+}
+
+func (s *petrickSearch) choose(prime, delta int) {
+	for _, m := range s.covers[prime] {
+		s.coverage[m] += delta
+	}
+	if delta > 0 {
+		s.selected = append(s.selected, prime)
+	} else {
+		s.selected = s.selected[:len(s.selected)-1]
+	}
+	s.count += delta
+	s.literalCount += delta * s.literals[prime]
+}
+
+// lowerBound picks uncovered minterms that share no usable prime. Each of them
+// needs a prime of its own, costing at least its cheapest option.
+func (s *petrickSearch) lowerBound() (int, int) {
+	s.mark++
+	count, literals := 0, 0
+	for m, primes := range s.coveredBy {
+		if s.coverage[m] > 0 {
+			continue
+		}
+
+		independent, cheapest := true, -1
+		for _, p := range primes {
+			if s.excluded[p] {
+				continue
+			}
+			if s.marks[p] == s.mark {
+				independent = false
+				break
+			}
+			if cheapest == -1 || s.literals[p] < cheapest {
+				cheapest = s.literals[p]
+			}
+		}
+		if !independent {
+			continue
+		}
+
+		for _, p := range primes {
+			s.marks[p] = s.mark
+		}
+		count++
+		literals += cheapest
+	}
+
+	return count, literals
+}
+
+// bitset marks the members of a set of chart rows or columns.
+type bitset []uint64
+
+func newBitset(size int) bitset {
+	return make(bitset, (size+63)/64)
+}
+
+func (b bitset) add(i int) {
+	b[i/64] |= 1 << (i % 64)
+}
+
+func (b bitset) subsetOf(other bitset) bool {
+	for i := range b {
+		if b[i]&^other[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// reduce applies the classic chart reductions until none is left: a minterm
+// with a single usable prime forces it, a minterm whose primes include all
+// primes of another minterm is covered along with it, and a prime whose
+// minterms are all covered by a prime with no more literals is dropped.
+// Dropped minterms get a permanent coverage count so the search skips them.
+func (s *petrickSearch) reduce() {
+	for changed := true; changed; {
+		changed = false
+
+		primeSets := make([]bitset, len(s.covers))
+		for p, minterms := range s.covers {
+			primeSets[p] = newBitset(len(s.coveredBy))
+			if s.excluded[p] {
+				continue
+			}
+			for _, m := range minterms {
+				if s.coverage[m] == 0 {
+					primeSets[p].add(m)
+				}
+			}
+		}
+
+		mintermSets := make([]bitset, len(s.coveredBy))
+		for m, primes := range s.coveredBy {
+			mintermSets[m] = newBitset(len(s.covers))
+			usable, last := 0, -1
+			for _, p := range primes {
+				if !s.excluded[p] {
+					mintermSets[m].add(p)
+					usable, last = usable+1, p
+				}
+			}
+			if s.coverage[m] == 0 && usable == 1 {
+				s.choose(last, 1)
+				changed = true
+			}
+		}
+		if changed {
+			continue
+		}
+
+		for a := range s.coveredBy {
+			if s.coverage[a] > 0 {
+				continue
+			}
+			for b := range s.coveredBy {
+				if a == b || s.coverage[b] > 0 || !mintermSets[a].subsetOf(mintermSets[b]) {
+					continue
+				}
+				if b < a && mintermSets[b].subsetOf(mintermSets[a]) {
+					continue
+				}
+				s.coverage[b]++
+				changed = true
+			}
+		}
+
+		for p := range s.covers {
+			if s.excluded[p] {
+				continue
+			}
+			for q := range s.covers {
+				if p == q || s.excluded[q] || s.literals[q] > s.literals[p] || !primeSets[p].subsetOf(primeSets[q]) {
+					continue
+				}
+				if q > p && s.literals[q] == s.literals[p] && primeSets[q].subsetOf(primeSets[p]) {
+					continue
+				}
+				s.excluded[p] = true
+				changed = true
+				break
+			}
+		}
+	}
+}
+
+// search reports false when it ran out of steps.
+func (s *petrickSearch) search() bool {
+	s.steps++
+	if s.steps > maxPetrickSteps {
+		return false
+	}
+
+	target, options := -1, 0
+	for m, primes := range s.coveredBy {
+		if s.coverage[m] > 0 {
+			continue
+		}
+		usable := 0
+		for _, p := range primes {
+			if !s.excluded[p] {
+				usable++
+			}
+		}
+		if usable == 0 {
+			return true
+		}
+		if target == -1 || usable < options {
+			target, options = m, usable
+		}
+	}
+
+	if target == -1 {
+		if s.improves(s.count, s.literalCount) {
+			s.best = append(s.best[:0], s.selected...)
+			s.bestCount, s.bestLiterals = s.count, s.literalCount
+		}
+		return true
+	}
+
+	boundCount, boundLiterals := s.lowerBound()
+	if !s.improves(s.count+boundCount, s.literalCount+boundLiterals) {
+		return true
+	}
+
+	tried := []int{}
+	defer func() {
+		for _, p := range tried {
+			s.excluded[p] = false
+		}
+	}()
+
+	for _, p := range s.coveredBy[target] {
+		if s.excluded[p] {
+			continue
+		}
+
+		s.choose(p, 1)
+		finished := s.search()
+		s.choose(p, -1)
+		if !finished {
+			return false
+		}
+
+		s.excluded[p] = true
+		tried = append(tried, p)
+	}
+
+	return true
+}
+
+// petrick finds a cheapest cover of the minterms, fewest primes first and
+// fewest literals second. It starts from the greedy cover and reports false
+// when the search ran out of steps before proving a cover minimal.
+func petrick(n int, primes []implicant, minterms []int) (*big.Int, bool) {
+	search := &petrickSearch{
+		literals:  make([]int, len(primes)),
+		coveredBy: make([][]int, len(minterms)),
+		covers:    make([][]int, len(primes)),
+		coverage:  make([]int, len(minterms)),
+		excluded:  make([]bool, len(primes)),
+		marks:     make([]int, len(primes)),
+	}
+	for i, prime := range primes {
+		search.literals[i] = prime.literals(n)
+		for m, minterm := range minterms {
+			if prime.covers(minterm) {
+				search.coveredBy[m] = append(search.coveredBy[m], i)
+				search.covers[i] = append(search.covers[i], m)
+			}
+		}
+	}
+
+	greedy := greedyCover(n, primes, minterms)
+	for i := range primes {
+		if greedy.Bit(i) == 1 {
+			search.best = append(search.best, i)
+			search.bestCount++
+			search.bestLiterals += search.literals[i]
+		}
+	}
+
+	search.reduce()
+	exact := search.search()
+
+	cover := new(big.Int)
+	for _, i := range search.best {
+		cover.SetBit(cover, i, 1)
+	}
+
+	return cover, exact
+}
+
+func greedyCover(n int, primes []implicant, minterms []int) *big.Int {
+	cover := new(big.Int)
+	remaining := append([]int{}, minterms...)
+
+	for len(remaining) > 0 {
+		bestIndex, bestCovered := -1, 0
+		for i, prime := range primes {
+			covered := 0
+			for _, minterm := range remaining {
+				if prime.covers(minterm) {
+					covered++
+				}
+			}
+			if covered > bestCovered || (covered == bestCovered && covered > 0 && prime.literals(n) < primes[bestIndex].literals(n)) {
+				bestIndex, bestCovered = i, covered
+			}
+		}
+
+		cover.SetBit(cover, bestIndex, 1)
+		left := remaining[:0]
+		for _, minterm := range remaining {
+			if !primes[bestIndex].covers(minterm) {
+				left = append(left, minterm)
+			}
+		}
+		remaining = left
+	}
+
+	return cover
+}
+
+type minimalCover struct {
+	primes    []implicant
+	essential []int
+	selected  []int
+	exact     bool
+}
+
+func findMinimalCover(n int, minterms, dontCares []int) minimalCover {
+	primes := primeImplicants(n, append(append([]int{}, minterms...), dontCares...))
+	result := minimalCover{primes: primes, exact: true}
+
+	chosen := make(map[int]bool)
+	for _, minterm := range minterms {
+		only := -1
+		for i, prime := range primes {
+			if prime.covers(minterm) {
+				if only != -1 {
+					only = -2
+					break
+				}
+				only = i
+			}
+		}
+		if only >= 0 && !chosen[only] {
+			chosen[only] = true
+			result.essential = append(result.essential, only)
+		}
+	}
+
+	remaining := []int{}
+	for _, minterm := range minterms {
+		covered := false
+		for i := range chosen {
+			if primes[i].covers(minterm) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			remaining = append(remaining, minterm)
+		}
+	}
+
+	if len(remaining) > 0 {
+		cover, exact := petrick(n, primes, remaining)
+		result.exact = exact
+		for i := range primes {
+			if cover.Bit(i) == 1 {
+				chosen[i] = true
+			}
+		}
+	}
+
+	for i := range primes {
+		if chosen[i] {
+			result.selected = append(result.selected, i)
+		}
+	}
+	sort.Ints(result.essential)
+
+	return result
+}
+
+func joinTerms(terms []string, separator string) string {
+	if len(terms) > 1 {
+		for i, term := range terms {
+			if strings.Contains(term, " ") {
+				terms[i] = "(" + term + ")"
+			}
+		}
+	}
+	return strings.Join(terms, separator)
+}
+
+func validateMinimizationTerms(n int, minterms, dontCares []int) error {
+	if n > maxMinimizationVariables {
+		return fmt.Errorf("minimization supports at most %d variables, got %d", maxMinimizationVariables, n)
+	}
+
+	seen := make(map[int]string)
+	for _, group := range []struct {
+		name  string
+		terms []int
+	}{{"minterm", minterms}, {"don't care", dontCares}} {
+		for _, t := range group.terms {
+			if t < 0 || t >= 1<<n {
+				return fmt.Errorf("%s %d is out of range for %d variables", group.name, t, n)
+			}
+			if previous, exists := seen[t]; exists {
+				return fmt.Errorf("%d is listed as both %s and %s", t, previous, group.name)
+			}
+			seen[t] = group.name
+		}
+	}
+
+	return nil
+}
+
+func defaultVariables(n int) []string {
+	variables := make([]string, n)
+	for i := range variables {
+		variables[i] = fmt.Sprintf("x%d", i+1)
+	}
+	return variables
+}
+
+// Minimize finds minimal DNF and CNF forms with Quine–McCluskey and Petrick's
+// method. Row indices use the first variable as the most significant bit.
+func (s *LogicSimplifier) Minimize(variables []string, minterms, dontCares []int) (Minimization, error) {
+	n := len(variables)
+	if err := validateMinimizationTerms(n, minterms, dontCares); err != nil {
+		return Minimization{}, err
+	}
+
+	minterms = append([]int{}, minterms...)
+	dontCares = append([]int{}, dontCares...)
+	sort.Ints(minterms)
+	sort.Ints(dontCares)
+
+	listed := make(map[int]struct{})
+	for _, t := range append(append([]int{}, minterms...), dontCares...) {
+		listed[t] = struct{}{}
+	}
+	maxterms := []int{}
+	for t := 0; t < 1<<n; t++ {
+		if _, exists := listed[t]; !exists {
+			maxterms = append(maxterms, t)
+		}
+	}
+
+	dnf := findMinimalCover(n, minterms, dontCares)
+	cnf := findMinimalCover(n, maxterms, dontCares)
+
+	result := Minimization{
+		Variables:           variables,
+		Minterms:            minterms,
+		DontCares:           dontCares,
+		PrimeImplicants:     []Implicant{},
+		EssentialImplicants: []Implicant{},
+		Chart:               [][]int{},
+		Selected:            []Implicant{},
+		Exact:               dnf.exact && cnf.exact,
+	}
+
+	// Primes that only cover don't cares never join a cover, so they are left
+	// out of the list and the chart; rows map prime indices to their position.
+	rows := make(map[int]int)
+	for i, prime := range dnf.primes {
+		implicant := Implicant{
+			Pattern:  prime.pattern(n),
+			Minterms: []int{},
+			Term:     prime.term(variables, false),
+		}
+		row := make([]int, len(minterms))
+		for j, minterm := range minterms {
+			if prime.covers(minterm) {
+				implicant.Minterms = append(implicant.Minterms, minterm)
+				row[j] = 1
+			}
+		}
+		if len(implicant.Minterms) == 0 {
+			continue
+		}
+		rows[i] = len(result.PrimeImplicants)
+		result.PrimeImplicants = append(result.PrimeImplicants, implicant)
+		result.Chart = append(result.Chart, row)
+	}
+
+	for _, i := range dnf.essential {
+		result.EssentialImplicants = append(result.EssentialImplicants, result.PrimeImplicants[rows[i]])
+	}
+
+	terms := []string{}
+	for _, i := range dnf.selected {
+		result.Selected = append(result.Selected, result.PrimeImplicants[rows[i]])
+		terms = append(terms, result.PrimeImplicants[rows[i]].Term)
+	}
+	result.MinimalDNF = "0"
+	if len(terms) > 0 {
+		result.MinimalDNF = joinTerms(terms, " ∨ ")
+	}
+
+	clauses := []string{}
+	for _, i := range cnf.selected {
+		clauses = append(clauses, cnf.primes[i].term(variables, true))
+	}
+	result.MinimalCNF = "1"
+	if len(clauses) > 0 {
+		result.MinimalCNF = joinTerms(clauses, " ∧ ")
+	}
+
+	return result, nil
+}
+
+func (s *LogicSimplifier) MinimizeExpression(expression string, variables []string) (Minimization, error) {
+	generator, err := NewOrderedTruthTableGenerator(expression, variables)
+	if err != nil {
+		return Minimization{}, err
+	}
+
+	table, err := generator.BuildTruthTable()
+	if err != nil {
+		return Minimization{}, err
+	}
+
+	if len(table.Variables) > maxMinimizationVariables {
+		return Minimization{}, fmt.Errorf("minimization supports at most %d variables, got %d", maxMinimizationVariables, len(table.Variables))
+	}
+
+	minterms := []int{}
+	for i, value := range table.Result {
+		if value == 1 {
+			minterms = append(minterms, i)
+		}
+	}
+
+	return s.Minimize(table.Variables, minterms, nil)
+}
+
+// MinimizeVector accepts a function vector where '-' or '*' marks a don't-care
+// row. Variables default to x1..xn.
+func (s *LogicSimplifier) MinimizeVector(vector string, variables []string) (Minimization, error) {
+	n := bits.Len(uint(len(vector))) - 1
+	if len(vector) == 0 || 1<<n != len(vector) {
+		return Minimization{}, fmt.Errorf("function vector length must be a power of two, got %d", len(vector))
+	}
+
+	if len(variables) == 0 {
+		variables = defaultVariables(n)
+	}
+	if len(variables) != n {
+		return Minimization{}, fmt.Errorf("function vector of length %d needs %d variables, got %d", len(vector), n, len(variables))
+	}
+
+	minterms, dontCares := []int{}, []int{}
+	for i, value := range vector {
+		switch value {
+		case '0':
+		case '1':
+			minterms = append(minterms, i)
+		case '-', '*':
+			dontCares = append(dontCares, i)
+		default:
+			return Minimization{}, fmt.Errorf("function vector may only contain 0, 1, - or *, got %q at position %d", value, i+1)
+		}
+	}
+
+	return s.Minimize(variables, minterms, dontCares)
+}
+
+// MinimizeTerms works from minterm and don't-care indices. Without explicit
+// variables it uses as many x1..xn as the largest index needs.
+func (s *LogicSimplifier) MinimizeTerms(minterms, dontCares []int, variables []string) (Minimization, error) {
+	if len(variables) == 0 {
+		largest := 1
+		for _, t := range append(append([]int{}, minterms...), dontCares...) {
+			if t > largest {
+				largest = t
+			}
+		}
+		variables = defaultVariables(bits.Len(uint(largest)))
+	}
+
+	return s.Minimize(variables, minterms, dontCares)
+}
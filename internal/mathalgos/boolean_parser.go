@@ -0,0 +1,383 @@
+package mathalgos
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+type booleanTokenKind int
+
+const (
+	tokenEnd booleanTokenKind = iota
+	tokenVariable
+	tokenConstant
+	tokenNot
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+)
+
+const (
+	operatorAnd        = "∧"
+	operatorOr         = "∨"
+	operatorXor        = "⊕"
+	operatorImplies    = "→"
+	operatorEquivalent = "↔"
+	operatorSheffer    = "↑"
+	operatorPeirce     = "↓"
+	operatorNot        = "¬"
+)
+
+const maxOperatorPrecedence = 4
+
+// The parser and the AST methods recurse once per nesting level, so input is
+// capped in length and in depth of parentheses, negations and implications.
+const (
+	maxExpressionLength = 4096
+	maxExpressionDepth  = 256
+)
+
+// Binary operators grouped by precedence, loosest first. Implication is the
+// only right-associative operator.
+var operatorPrecedence = map[string]int{
+	operatorEquivalent: 1,
+	operatorImplies:    2,
+	operatorOr:         3,
+	operatorXor:        3,
+	operatorPeirce:     3,
+	operatorAnd:        4,
+	operatorSheffer:    4,
+}
+
+var operatorAliases = []struct {
+	text     string
+	operator string
+}{
+	{"<->", operatorEquivalent},
+	{"<=>", operatorEquivalent},
+	{"≡", operatorEquivalent},
+	{"->", operatorImplies},
+	{"=>", operatorImplies},
+	{"⇒", operatorImplies},
+	{"⇔", operatorEquivalent},
+	{"&&", operatorAnd},
+	{"||", operatorOr},
+	{"&", operatorAnd},
+	{"*", operatorAnd},
+	{"·", operatorAnd},
+	{"|", operatorOr},
+	{"+", operatorOr},
+	{"^", operatorXor},
+	{"!", operatorNot},
+	{"~", operatorNot},
+	{"∧", operatorAnd},
+	{"∨", operatorOr},
+	{"⊕", operatorXor},
+	{"→", operatorImplies},
+	{"↔", operatorEquivalent},
+	{"↑", operatorSheffer},
+	{"↓", operatorPeirce},
+	{"¬", operatorNot},
+}
+
+type booleanToken struct {
+	kind     booleanTokenKind
+	text     string
+	position int
+}
+
+type ExpressionError struct {
+	Position int
+	Message  string
+}
+
+func (e *ExpressionError) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Position, e.Message)
+}
+
+type BooleanExpression interface {
+	Evaluate(values map[string]bool) bool
+	Variables() []string
+}
+
+type booleanConstant bool
+
+type booleanVariable string
+
+type booleanNot struct {
+	operand BooleanExpression
+}
+
+type booleanBinary struct {
+	operator    string
+	left, right BooleanExpression
+}
+
+func (c booleanConstant) Evaluate(map[string]bool) bool {
+	return bool(c)
+}
+
+func (c booleanConstant) Variables() []string {
+	return []string{}
+}
+
+func (v booleanVariable) Evaluate(values map[string]bool) bool {
+	return values[string(v)]
+}
+
+func (v booleanVariable) Variables() []string {
+	return []string{string(v)}
+}
+
+func (n booleanNot) Evaluate(values map[string]bool) bool {
+	return !n.operand.Evaluate(values)
+}
+
+func (n booleanNot) Variables() []string {
+	return n.operand.Variables()
+}
+
+func (b booleanBinary) Evaluate(values map[string]bool) bool {
+	left, right := b.left.Evaluate(values), b.right.Evaluate(values)
+
+	switch b.operator {
+	case operatorAnd:
+		return left && right
+	case operatorOr:
+		return left || right
+	case operatorXor:
+		return left != right
+	case operatorImplies:
+		return !left || right
+	case operatorEquivalent:
+		return left == right
+	case operatorSheffer:
+		return !(left && right)
+	case operatorPeirce:
+		return !(left || right)
+	}
+
+	return false
+}
+
+func (b booleanBinary) Variables() []string {
+	return mergeUnique(b.left.Variables(), b.right.Variables())
+}
+
+func tokenizeBooleanExpression(expression string) ([]booleanToken, error) {
+	runes := []rune(expression)
+	tokens := []booleanToken{}
+
+	hasPrefix := func(i int, prefix string) bool {
+		prefixRunes := []rune(prefix)
+		if i+len(prefixRunes) > len(runes) {
+			return false
+		}
+		return string(runes[i:i+len(prefixRunes)]) == prefix
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		position := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '(':
+			tokens = append(tokens, booleanToken{kind: tokenLeftParen, text: "(", position: position})
+			i++
+			continue
+		case r == ')':
+			tokens = append(tokens, booleanToken{kind: tokenRightParen, text: ")", position: position})
+			i++
+			continue
+		case r == '0' || r == '1':
+			if i+1 < len(runes) && unicode.IsDigit(runes[i+1]) {
+				return nil, &ExpressionError{Position: position, Message: "constants must be 0 or 1"}
+			}
+			tokens = append(tokens, booleanToken{kind: tokenConstant, text: string(r), position: position})
+			i++
+			continue
+		case unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			name := string(runes[start:i])
+			switch name {
+			case "true":
+				tokens = append(tokens, booleanToken{kind: tokenConstant, text: "1", position: position})
+			case "false":
+				tokens = append(tokens, booleanToken{kind: tokenConstant, text: "0", position: position})
+			default:
+				tokens = append(tokens, booleanToken{kind: tokenVariable, text: name, position: position})
+			}
+			continue
+		}
+
+		matched := false
+		for _, alias := range operatorAliases {
+			if hasPrefix(i, alias.text) {
+				kind := tokenOperator
+				if alias.operator == operatorNot {
+					kind = tokenNot
+				}
+				tokens = append(tokens, booleanToken{kind: kind, text: alias.operator, position: position})
+				i += len([]rune(alias.text))
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return nil, &ExpressionError{Position: position, Message: fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+
+	tokens = append(tokens, booleanToken{kind: tokenEnd, position: len(runes) + 1})
+	return tokens, nil
+}
+
+type booleanParser struct {
+	tokens []booleanToken
+	pos    int
+	depth  int
+}
+
+func (p *booleanParser) peek() booleanToken {
+	return p.tokens[p.pos]
+}
+
+func (p *booleanParser) next() booleanToken {
+	token := p.tokens[p.pos]
+	if token.kind != tokenEnd {
+		p.pos++
+	}
+	return token
+}
+
+// nest enters one more nesting level at token; callers leave it with
+// p.depth-- once the nested operand is parsed.
+func (p *booleanParser) nest(token booleanToken) error {
+	p.depth++
+	if p.depth > maxExpressionDepth {
+		return &ExpressionError{Position: token.position, Message: fmt.Sprintf("expression is nested deeper than %d levels", maxExpressionDepth)}
+	}
+	return nil
+}
+
+func unexpectedToken(token booleanToken) error {
+	if token.kind == tokenEnd {
+		return &ExpressionError{Position: token.position, Message: "unexpected end of expression"}
+	}
+	return &ExpressionError{Position: token.position, Message: fmt.Sprintf("unexpected %q", token.text)}
+}
+
+func (p *booleanParser) parseBinary(precedence int) (BooleanExpression, error) {
+	if precedence > maxOperatorPrecedence {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseBinary(precedence + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		token := p.peek()
+		if token.kind != tokenOperator || operatorPrecedence[token.text] != precedence {
+			return left, nil
+		}
+		p.next()
+
+		var right BooleanExpression
+		if token.text == operatorImplies {
+			if err := p.nest(token); err != nil {
+				return nil, err
+			}
+			right, err = p.parseBinary(precedence)
+			p.depth--
+		} else {
+			right, err = p.parseBinary(precedence + 1)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		left = booleanBinary{operator: token.text, left: left, right: right}
+		if token.text == operatorImplies {
+			return left, nil
+		}
+	}
+}
+
+func (p *booleanParser) parseUnary() (BooleanExpression, error) {
+	token := p.next()
+
+	switch token.kind {
+	case tokenNot:
+		if err := p.nest(token); err != nil {
+			return nil, err
+		}
+		operand, err := p.parseUnary()
+		p.depth--
+		if err != nil {
+			return nil, err
+		}
+		return booleanNot{operand: operand}, nil
+	case tokenConstant:
+		return booleanConstant(token.text == "1"), nil
+	case tokenVariable:
+		return booleanVariable(token.text), nil
+	case tokenLeftParen:
+		if err := p.nest(token); err != nil {
+			return nil, err
+		}
+		inner, err := p.parseBinary(1)
+		p.depth--
+		if err != nil {
+			return nil, err
+		}
+		closing := p.next()
+		if closing.kind != tokenRightParen {
+			if closing.kind == tokenEnd {
+				return nil, &ExpressionError{Position: token.position, Message: "unclosed parenthesis"}
+			}
+			return nil, &ExpressionError{Position: closing.position, Message: fmt.Sprintf("expected \")\", got %q", closing.text)}
+		}
+		return inner, nil
+	}
+
+	return nil, unexpectedToken(token)
+}
+
+// ParseBooleanExpression builds an AST for a Boolean formula. Positions in
+// returned errors are 1-based character offsets into the expression.
+func ParseBooleanExpression(expression string) (BooleanExpression, error) {
+	if length := utf8.RuneCountInString(expression); length > maxExpressionLength {
+		return nil, &ExpressionError{Position: maxExpressionLength + 1, Message: fmt.Sprintf("expression is longer than %d characters", maxExpressionLength)}
+	}
+
+	tokens, err := tokenizeBooleanExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &booleanParser{tokens: tokens}
+	if parser.peek().kind == tokenEnd {
+		return nil, &ExpressionError{Position: 1, Message: "empty expression"}
+	}
+
+	root, err := parser.parseBinary(1)
+	if err != nil {
+		return nil, err
+	}
+
+	if token := parser.peek(); token.kind != tokenEnd {
+		return nil, unexpectedToken(token)
+	}
+
+	return root, nil
+}
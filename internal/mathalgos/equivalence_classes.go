@@ -0,0 +1,71 @@
+package mathalgos
+
+import (
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+)
+
+// EquivalenceClasses lists the classes in order of their first element, so
+// Classes is the quotient set and Representatives[i] generates Classes[i].
+type EquivalenceClasses struct {
+	IsEquivalence    bool
+	FailedProperties RelationProperties
+	Classes          [][]string
+	Representatives  []string
+}
+
+func GetEquivalenceClasses(model models.BinaryRelationModel) EquivalenceClasses {
+	elements := getRelationElements(model)
+	relation := getRelationSet(model)
+
+	failedProperties := RelationProperties{}
+	checks := []struct {
+		key     string
+		checker func([]string, map[[2]string]struct{}) RelationProperties
+	}{
+		{"reflexive", checkReflexiveProperty},
+		{"symmetric", checkSymmetryProperties},
+		{"transitive", checkTransitivityProperties},
+	}
+	for _, check := range checks {
+		for _, property := range check.checker(elements, relation) {
+			if property.Key == check.key && !property.Holds {
+				failedProperties = append(failedProperties, property)
+			}
+		}
+	}
+
+	if len(failedProperties) > 0 {
+		return EquivalenceClasses{
+			IsEquivalence:    false,
+			FailedProperties: failedProperties,
+		}
+	}
+
+	classes := [][]string{}
+	representatives := []string{}
+	assigned := make(map[string]struct{})
+
+	for _, e := range elements {
+		if _, exists := assigned[e]; exists {
+			continue
+		}
+
+		class := []string{}
+		for _, other := range elements {
+			if _, related := relation[[2]string{e, other}]; related {
+				class = append(class, other)
+				assigned[other] = struct{}{}
+			}
+		}
+
+		classes = append(classes, class)
+		representatives = append(representatives, e)
+	}
+
+	return EquivalenceClasses{
+		IsEquivalence:    true,
+		FailedProperties: failedProperties,
+		Classes:          classes,
+		Representatives:  representatives,
+	}
+}
@@ -0,0 +1,153 @@
+package mathalgos
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+)
+
+type FunctionAnalysis struct {
+	Domain              []string
+	Codomain            []string
+	IsTotal             bool
+	IsFunctional        bool
+	IsFunction          bool
+	IsInjective         bool
+	IsSurjective        bool
+	IsBijective         bool
+	UnmappedElements    []string
+	MultivaluedElements []string
+	SharedImages        []string
+	UncoveredElements   []string
+	Inverse             [][2]string
+}
+
+// NormalizeFunctionModel prepares a relation R ⊆ A × B for AnalyzeFunction. A
+// matrix is read with rows in domain order and columns in codomain order, so
+// it is |A| × |B| rather than square over A ∪ B.
+func NormalizeFunctionModel(model models.BinaryRelationModel) (models.BinaryRelationModel, error) {
+	if duplicates := duplicateElements(model.Domain); len(duplicates) > 0 {
+		return models.BinaryRelationModel{}, fmt.Errorf("duplicate elements in domain: %s", strings.Join(duplicates, ", "))
+	}
+	if duplicates := duplicateElements(model.Codomain); len(duplicates) > 0 {
+		return models.BinaryRelationModel{}, fmt.Errorf("duplicate elements in codomain: %s", strings.Join(duplicates, ", "))
+	}
+
+	if len(model.Matrix) > 0 && len(model.Domain) > 0 {
+		codomain := model.Codomain
+		if len(codomain) == 0 {
+			codomain = model.Domain
+		}
+
+		relation, err := matrixPairs(model.Domain, codomain, model.Matrix)
+		if err != nil {
+			return models.BinaryRelationModel{}, err
+		}
+
+		if len(model.BinaryRelation) > 0 {
+			fromMatrix := models.BinaryRelationModel{BinaryRelation: relation}
+			if !maps.Equal(getRelationSet(model), getRelationSet(fromMatrix)) {
+				return models.BinaryRelationModel{}, fmt.Errorf("binary_relation and matrix describe different relations")
+			}
+		}
+
+		model.BinaryRelation = relation
+		model.Matrix = nil
+	}
+
+	return NormalizeRelationModel(model)
+}
+
+func AnalyzeFunction(model models.BinaryRelationModel) (FunctionAnalysis, error) {
+	domain := model.Domain
+	if len(domain) == 0 {
+		domain = getRelationElements(model)
+	}
+
+	codomain := model.Codomain
+	if len(codomain) == 0 {
+		codomain = getRelationElements(model)
+	}
+
+	inDomain := make(map[string]struct{})
+	for _, e := range domain {
+		inDomain[e] = struct{}{}
+	}
+
+	inCodomain := make(map[string]struct{})
+	for _, e := range codomain {
+		inCodomain[e] = struct{}{}
+	}
+
+	relation := getRelationSet(model)
+
+	invalidPairs := []string{}
+	images := make(map[string][]string)
+	preimages := make(map[string][]string)
+
+	for _, pair := range model.BinaryRelation {
+		_, fromExists := inDomain[pair[0]]
+		_, toExists := inCodomain[pair[1]]
+		if !fromExists || !toExists {
+			invalidPairs = append(invalidPairs, fmt.Sprintf("(%s, %s)", pair[0], pair[1]))
+		}
+	}
+
+	if len(invalidPairs) > 0 {
+		return FunctionAnalysis{}, fmt.Errorf("pairs outside domain × codomain: %s", strings.Join(invalidPairs, ", "))
+	}
+
+	for _, a := range domain {
+		for _, b := range codomain {
+			if _, exists := relation[[2]string{a, b}]; exists {
+				images[a] = append(images[a], b)
+				preimages[b] = append(preimages[b], a)
+			}
+		}
+	}
+
+	analysis := FunctionAnalysis{
+		Domain:              domain,
+		Codomain:            codomain,
+		UnmappedElements:    []string{},
+		MultivaluedElements: []string{},
+		SharedImages:        []string{},
+		UncoveredElements:   []string{},
+	}
+
+	for _, a := range domain {
+		switch {
+		case len(images[a]) == 0:
+			analysis.UnmappedElements = append(analysis.UnmappedElements, a)
+		case len(images[a]) > 1:
+			analysis.MultivaluedElements = append(analysis.MultivaluedElements, a)
+		}
+	}
+
+	for _, b := range codomain {
+		switch {
+		case len(preimages[b]) == 0:
+			analysis.UncoveredElements = append(analysis.UncoveredElements, b)
+		case len(preimages[b]) > 1:
+			analysis.SharedImages = append(analysis.SharedImages, b)
+		}
+	}
+
+	analysis.IsTotal = len(analysis.UnmappedElements) == 0
+	analysis.IsFunctional = len(analysis.MultivaluedElements) == 0
+	analysis.IsFunction = analysis.IsTotal && analysis.IsFunctional
+	analysis.IsInjective = analysis.IsFunction && len(analysis.SharedImages) == 0
+	analysis.IsSurjective = analysis.IsFunction && len(analysis.UncoveredElements) == 0
+	analysis.IsBijective = analysis.IsInjective && analysis.IsSurjective
+
+	if analysis.IsBijective {
+		analysis.Inverse = [][2]string{}
+		for _, b := range codomain {
+			analysis.Inverse = append(analysis.Inverse, [2]string{b, preimages[b][0]})
+		}
+	}
+
+	return analysis, nil
+}
@@ -0,0 +1,1006 @@
+package mathalgos
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/image/colornames"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding"
+	"gonum.org/v1/gonum/graph/encoding/dot"
+)
+
+const (
+	BuiltinRenderer  = "builtin"
+	GraphvizRenderer = "graphviz"
+
+	CircularLayout = "circular"
+	LayeredLayout  = "layered"
+)
+
+const (
+	nodeRadius     = 22.0
+	edgeWidth      = 1.5
+	arrowLength    = 10.0
+	arrowHalfWidth = 5.0
+	drawingMargin  = 60.0
+	titleHeight    = 40.0
+	layerSpacing   = 100.0
+	nodeSpacing    = 90.0
+	fontWidth      = 7.0
+	maxImagePixels = 4096 * 4096
+)
+
+type GraphDrawing struct {
+	Title     string
+	Nodes     []DrawingNode
+	Edges     []DrawingEdge
+	Directed  bool
+	Direction string
+	DPI       float64
+}
+
+type DrawingNode struct {
+	Label string
+	Fill  color.RGBA
+}
+
+type DrawingEdge struct {
+	From  int
+	To    int
+	Color color.RGBA
+	Width float64
+}
+
+type point struct {
+	x, y float64
+}
+
+func (p point) add(q point) point {
+	return point{p.x + q.x, p.y + q.y}
+}
+
+func (p point) sub(q point) point {
+	return point{p.x - q.x, p.y - q.y}
+}
+
+func (p point) scale(k float64) point {
+	return point{p.x * k, p.y * k}
+}
+
+func (p point) length() float64 {
+	return math.Hypot(p.x, p.y)
+}
+
+func (p point) unit() point {
+	length := p.length()
+	if length == 0 {
+		return point{0, -1}
+	}
+	return p.scale(1 / length)
+}
+
+func (p point) normal() point {
+	return point{-p.y, p.x}
+}
+
+type drawingPrimitive struct {
+	kind        string
+	points      []point
+	radius      float64
+	fill        *color.RGBA
+	stroke      *color.RGBA
+	strokeWidth float64
+	text        string
+}
+
+type drawingScene struct {
+	dpi        float64
+	width      float64
+	height     float64
+	primitives []drawingPrimitive
+}
+
+var (
+	whiteColor   = color.RGBA{255, 255, 255, 255}
+	blackColor   = color.RGBA{0, 0, 0, 255}
+	skyblueColor = color.RGBA{135, 206, 235, 255}
+)
+
+func attributeValue(attributes []encoding.Attribute, key string) string {
+	for _, attribute := range attributes {
+		if attribute.Key == key {
+			return attribute.Value
+		}
+	}
+
+	return ""
+}
+
+func parseColor(value string, fallback color.RGBA) color.RGBA {
+	if parsed, ok := lookupColor(value); ok {
+		return parsed
+	}
+
+	return fallback
+}
+
+// lookupColor accepts an SVG color name or a #rrggbb hex triplet.
+func lookupColor(value string) (color.RGBA, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	if named, exists := colornames.Map[value]; exists {
+		return named, true
+	}
+
+	if strings.HasPrefix(value, "#") && len(value) == 7 {
+		var r, g, b uint8
+		if _, err := fmt.Sscanf(value, "#%02x%02x%02x", &r, &g, &b); err == nil {
+			return color.RGBA{r, g, b, 255}, true
+		}
+	}
+
+	return color.RGBA{}, false
+}
+
+func parseWidth(value string) float64 {
+	width, err := strconv.ParseFloat(value, 64)
+	if err != nil || width <= 0 {
+		return edgeWidth
+	}
+
+	return width
+}
+
+func NewGraphDrawing(g graph.Directed) *GraphDrawing {
+	drawing := &GraphDrawing{Directed: true, Direction: "TB", DPI: defaultDPI}
+
+	if attributers, ok := g.(dot.Attributers); ok {
+		graphAttributes, _, edgeAttributes := attributers.DOTAttributers()
+		drawing.Title = attributeValue(graphAttributes.Attributes(), "label")
+		if direction := attributeValue(graphAttributes.Attributes(), "rankdir"); direction != "" {
+			drawing.Direction = direction
+		}
+		if dpi, err := strconv.ParseFloat(attributeValue(graphAttributes.Attributes(), "dpi"), 64); err == nil && dpi > 0 {
+			drawing.DPI = dpi
+		}
+		drawing.Directed = attributeValue(edgeAttributes.Attributes(), "arrowhead") != "none"
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID() < nodes[j].ID()
+	})
+
+	indices := make(map[int64]int)
+	for i, node := range nodes {
+		indices[node.ID()] = i
+
+		drawingNode := DrawingNode{Label: fmt.Sprint(node.ID()), Fill: skyblueColor}
+		if attributer, ok := node.(encoding.Attributer); ok {
+			attributes := attributer.Attributes()
+			if label := attributeValue(attributes, "label"); label != "" {
+				drawingNode.Label = label
+			}
+			fill := attributeValue(attributes, "fillcolor")
+			if fill == "" {
+				fill = attributeValue(attributes, "color")
+			}
+			drawingNode.Fill = parseColor(fill, skyblueColor)
+		}
+
+		drawing.Nodes = append(drawing.Nodes, drawingNode)
+	}
+
+	for _, node := range nodes {
+		successors := graph.NodesOf(g.From(node.ID()))
+		sort.Slice(successors, func(i, j int) bool {
+			return successors[i].ID() < successors[j].ID()
+		})
+
+		for _, successor := range successors {
+			var attributes []encoding.Attribute
+			if multigraph, ok := g.(graph.Multigraph); ok {
+				lines := graph.LinesOf(multigraph.Lines(node.ID(), successor.ID()))
+				if len(lines) > 0 {
+					if attributer, ok := lines[0].(encoding.Attributer); ok {
+						attributes = attributer.Attributes()
+					}
+				}
+			} else if attributer, ok := g.Edge(node.ID(), successor.ID()).(encoding.Attributer); ok {
+				attributes = attributer.Attributes()
+			}
+
+			drawing.Edges = append(drawing.Edges, DrawingEdge{
+				From:  indices[node.ID()],
+				To:    indices[successor.ID()],
+				Color: parseColor(attributeValue(attributes, "color"), blackColor),
+				Width: parseWidth(attributeValue(attributes, "penwidth")),
+			})
+		}
+	}
+
+	return drawing
+}
+
+func (d *GraphDrawing) Render(layout, format string) ([]byte, error) {
+	return d.buildScene(layout).render(format)
+}
+
+func (s *drawingScene) render(format string) ([]byte, error) {
+	switch format {
+	case "png":
+		return s.png()
+	case "svg":
+		return s.svg(), nil
+	case "pdf":
+		return s.pdf()
+	default:
+		return nil, fmt.Errorf("unsupported image format: %q", format)
+	}
+}
+
+func (d *GraphDrawing) circularLayout() ([]point, point, float64, float64) {
+	n := len(d.Nodes)
+	radius := math.Max(120, float64(n)*nodeRadius*2.4/(2*math.Pi))
+	size := 2*(radius+drawingMargin) + 2*nodeRadius
+	center := point{size / 2, size/2 + titleHeight}
+
+	positions := make([]point, n)
+	for i := range positions {
+		if n == 1 {
+			positions[i] = center
+			continue
+		}
+		angle := 2*math.Pi*float64(i)/float64(n) - math.Pi/2
+		positions[i] = center.add(point{math.Cos(angle), math.Sin(angle)}.scale(radius))
+	}
+
+	return positions, center, size, size + titleHeight
+}
+
+func (d *GraphDrawing) assignLayers() []int {
+	n := len(d.Nodes)
+	successors := make([][]int, n)
+	for _, edge := range d.Edges {
+		if edge.From != edge.To {
+			successors[edge.From] = append(successors[edge.From], edge.To)
+		}
+	}
+
+	state := make([]int, n)
+	acyclic := make([][]int, n)
+
+	var visit func(v int)
+	visit = func(v int) {
+		state[v] = 1
+		for _, w := range successors[v] {
+			switch state[w] {
+			case 0:
+				acyclic[v] = append(acyclic[v], w)
+				visit(w)
+			case 2:
+				acyclic[v] = append(acyclic[v], w)
+			}
+		}
+		state[v] = 2
+	}
+
+	for v := 0; v < n; v++ {
+		if state[v] == 0 {
+			visit(v)
+		}
+	}
+
+	layers := make([]int, n)
+	changed := true
+	for changed {
+		changed = false
+		for v := 0; v < n; v++ {
+			for _, w := range acyclic[v] {
+				if layers[w] < layers[v]+1 {
+					layers[w] = layers[v] + 1
+					changed = true
+				}
+			}
+		}
+	}
+
+	return layers
+}
+
+func (d *GraphDrawing) layeredLayout() ([]point, float64, float64) {
+	n := len(d.Nodes)
+	layers := d.assignLayers()
+
+	layerCount := 0
+	for _, layer := range layers {
+		if layer+1 > layerCount {
+			layerCount = layer + 1
+		}
+	}
+
+	rows := make([][]int, layerCount)
+	for v := 0; v < n; v++ {
+		rows[layers[v]] = append(rows[layers[v]], v)
+	}
+
+	predecessors := make([][]int, n)
+	for _, edge := range d.Edges {
+		if edge.From != edge.To {
+			predecessors[edge.To] = append(predecessors[edge.To], edge.From)
+		}
+	}
+
+	order := make([]float64, n)
+	for _, row := range rows {
+		for i, v := range row {
+			order[v] = float64(i)
+		}
+	}
+
+	for sweep := 0; sweep < 4; sweep++ {
+		for l := 1; l < layerCount; l++ {
+			barycenters := make(map[int]float64)
+			for _, v := range rows[l] {
+				sum, count := 0.0, 0
+				for _, u := range predecessors[v] {
+					if layers[u] < l {
+						sum += order[u]
+						count++
+					}
+				}
+				if count > 0 {
+					barycenters[v] = sum / float64(count)
+				} else {
+					barycenters[v] = order[v]
+				}
+			}
+
+			row := rows[l]
+			for i := 1; i < len(row); i++ {
+				for j := i; j > 0 && barycenters[row[j]] < barycenters[row[j-1]]; j-- {
+					row[j], row[j-1] = row[j-1], row[j]
+				}
+			}
+			for i, v := range row {
+				order[v] = float64(i)
+			}
+		}
+	}
+
+	widest := 1
+	for _, row := range rows {
+		if len(row) > widest {
+			widest = len(row)
+		}
+	}
+
+	across := float64(widest-1)*nodeSpacing + 2*(drawingMargin+nodeRadius)
+	along := float64(layerCount-1)*layerSpacing + 2*(drawingMargin+nodeRadius)
+
+	horizontal := d.Direction == "LR" || d.Direction == "RL"
+	reversed := d.Direction == "BT" || d.Direction == "RL"
+
+	width, height := across, along+titleHeight
+	if horizontal {
+		width, height = along, across+titleHeight
+	}
+
+	positions := make([]point, n)
+	for l, row := range rows {
+		offset := (across - float64(len(row)-1)*nodeSpacing) / 2
+		rank := drawingMargin + nodeRadius + float64(l)*layerSpacing
+		if reversed {
+			rank = along - rank
+		}
+		for i, v := range row {
+			if horizontal {
+				positions[v] = point{rank, titleHeight + offset + float64(i)*nodeSpacing}
+			} else {
+				positions[v] = point{offset + float64(i)*nodeSpacing, titleHeight + rank}
+			}
+		}
+	}
+
+	return positions, width, height
+}
+
+func (d *GraphDrawing) buildScene(layout string) *drawingScene {
+	var positions []point
+	var center point
+	var width, height float64
+
+	if layout == LayeredLayout {
+		positions, width, height = d.layeredLayout()
+	} else {
+		positions, center, width, height = d.circularLayout()
+	}
+
+	if len(d.Nodes) == 0 {
+		width, height = 2*drawingMargin, 2*drawingMargin+titleHeight
+	}
+
+	scene := &drawingScene{dpi: d.DPI, width: width, height: height}
+
+	edges := make(map[[2]int]struct{})
+	for _, edge := range d.Edges {
+		edges[[2]int{edge.From, edge.To}] = struct{}{}
+	}
+
+	for _, edge := range d.Edges {
+		edgeColor := edge.Color
+		from, to := positions[edge.From], positions[edge.To]
+
+		if edge.From == edge.To {
+			direction := point{0, -1}
+			if layout != LayeredLayout && from != center {
+				direction = from.sub(center).unit()
+			}
+			scene.addLoop(from, direction, edgeColor, edge.Width, d.Directed)
+			continue
+		}
+
+		_, hasReverse := edges[[2]int{edge.To, edge.From}]
+		scene.addEdge(from, to, hasReverse && d.Directed, edgeColor, edge.Width, d.Directed)
+	}
+
+	for i, node := range d.Nodes {
+		fill := node.Fill
+		stroke := blackColor
+		scene.primitives = append(scene.primitives, drawingPrimitive{
+			kind:        "circle",
+			points:      []point{positions[i]},
+			radius:      nodeRadius,
+			fill:        &fill,
+			stroke:      &stroke,
+			strokeWidth: 1,
+		})
+		scene.addText(positions[i], node.Label, blackColor)
+	}
+
+	if d.Title != "" {
+		scene.addText(point{width / 2, titleHeight / 2}, d.Title, blackColor)
+	}
+
+	return scene
+}
+
+func (s *drawingScene) addText(position point, text string, textColor color.RGBA) {
+	s.primitives = append(s.primitives, drawingPrimitive{
+		kind:   "text",
+		points: []point{position},
+		fill:   &textColor,
+		text:   text,
+	})
+}
+
+func (s *drawingScene) addLine(points []point, lineColor color.RGBA, width float64) {
+	s.primitives = append(s.primitives, drawingPrimitive{
+		kind:        "polyline",
+		points:      points,
+		stroke:      &lineColor,
+		strokeWidth: width,
+	})
+}
+
+func (s *drawingScene) addArrow(tip, direction point, arrowColor color.RGBA) {
+	base := tip.sub(direction.scale(arrowLength))
+	side := direction.normal().scale(arrowHalfWidth)
+	s.primitives = append(s.primitives, drawingPrimitive{
+		kind:   "polygon",
+		points: []point{tip, base.add(side), base.sub(side)},
+		fill:   &arrowColor,
+	})
+}
+
+func (s *drawingScene) addEdge(from, to point, curved bool, edgeColor color.RGBA, width float64, directed bool) {
+	direction := to.sub(from).unit()
+	control := from.add(to).scale(0.5)
+	if curved {
+		control = control.add(direction.normal().scale(30))
+	}
+
+	start := from.add(control.sub(from).unit().scale(nodeRadius))
+	end := to.add(control.sub(to).unit().scale(nodeRadius))
+
+	points := []point{start}
+	if curved {
+		for i := 1; i <= 16; i++ {
+			t := float64(i) / 16
+			a := start.scale((1 - t) * (1 - t))
+			b := control.scale(2 * (1 - t) * t)
+			c := end.scale(t * t)
+			points = append(points, a.add(b).add(c))
+		}
+	} else {
+		points = append(points, end)
+	}
+
+	if directed {
+		arrowDirection := end.sub(control).unit()
+		if !curved {
+			arrowDirection = direction
+		}
+		points[len(points)-1] = end.sub(arrowDirection.scale(arrowLength * 0.8))
+		s.addArrow(end, arrowDirection, edgeColor)
+	}
+
+	s.primitives = append(s.primitives, drawingPrimitive{
+		kind:        "polyline",
+		points:      points,
+		stroke:      &edgeColor,
+		strokeWidth: width,
+	})
+}
+
+func (s *drawingScene) addLoop(position, direction point, loopColor color.RGBA, width float64, directed bool) {
+	loopRadius := nodeRadius * 0.6
+	loopCenter := position.add(direction.scale(nodeRadius + loopRadius*0.5))
+
+	s.primitives = append(s.primitives, drawingPrimitive{
+		kind:        "circle",
+		points:      []point{loopCenter},
+		radius:      loopRadius,
+		stroke:      &loopColor,
+		strokeWidth: width,
+	})
+
+	if !directed {
+		return
+	}
+
+	distance := loopCenter.sub(position).length()
+	a := (distance*distance - loopRadius*loopRadius + nodeRadius*nodeRadius) / (2 * distance)
+	h := math.Sqrt(math.Max(nodeRadius*nodeRadius-a*a, 0))
+	base := position.add(direction.scale(a))
+	tip := base.add(direction.normal().scale(h))
+
+	tangent := tip.sub(loopCenter).normal().unit()
+	if tangent.x*(position.x-tip.x)+tangent.y*(position.y-tip.y) < 0 {
+		tangent = tangent.scale(-1)
+	}
+	s.addArrow(tip, tangent, loopColor)
+}
+
+func circlePath(r *vector.Rasterizer, c point, radius float64, reverse bool) {
+	const k = 0.5522847498
+	sign := 1.0
+	if reverse {
+		sign = -1.0
+	}
+
+	x, y := float32(c.x), float32(c.y)
+	rr := float32(radius)
+	kr := float32(radius * k)
+	sy := float32(sign)
+
+	r.MoveTo(x+rr, y)
+	r.CubeTo(x+rr, y+sy*kr, x+kr, y+sy*rr, x, y+sy*rr)
+	r.CubeTo(x-kr, y+sy*rr, x-rr, y+sy*kr, x-rr, y)
+	r.CubeTo(x-rr, y-sy*kr, x-kr, y-sy*rr, x, y-sy*rr)
+	r.CubeTo(x+kr, y-sy*rr, x+rr, y-sy*kr, x+rr, y)
+	r.ClosePath()
+}
+
+func (s *drawingScene) scaled(k float64) *drawingScene {
+	result := &drawingScene{dpi: s.dpi, width: s.width * k, height: s.height * k}
+	for _, primitive := range s.primitives {
+		points := make([]point, len(primitive.points))
+		for i, p := range primitive.points {
+			points[i] = p.scale(k)
+		}
+		primitive.points = points
+		primitive.radius *= k
+		primitive.strokeWidth *= k
+		result.primitives = append(result.primitives, primitive)
+	}
+
+	return result
+}
+
+func scaledFace(k float64) (font.Face, error) {
+	mono, err := opentype.Parse(gomono.TTF)
+	if err != nil {
+		return nil, err
+	}
+
+	return opentype.NewFace(mono, &opentype.FaceOptions{
+		Size:    fontWidth / 0.6 * k,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+}
+
+func (s *drawingScene) png() ([]byte, error) {
+	k := 1.0
+	if s.dpi > 0 {
+		k = s.dpi / defaultDPI
+	}
+	// Large graphs at high DPI are scaled down to the pixel budget, since the
+	// canvas and the rasterizer each hold a buffer of the full image size.
+	if pixels := s.width * s.height * k * k; pixels > maxImagePixels {
+		k *= math.Sqrt(maxImagePixels / pixels)
+	}
+	if k != 1 {
+		s = s.scaled(k)
+	}
+
+	face, err := scaledFace(k)
+	if err != nil {
+		return nil, fmt.Errorf("error loading font: %v", err)
+	}
+
+	width, height := int(math.Ceil(s.width)), int(math.Ceil(s.height))
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{whiteColor}, image.Point{}, draw.Src)
+
+	// Every shape is rasterized within its own bounding box, so its cost
+	// depends on its area rather than on the size of the whole canvas.
+	r := vector.NewRasterizer(0, 0)
+	var bounds image.Rectangle
+	var origin point
+	beginPath := func(primitive drawingPrimitive) {
+		margin := primitive.radius + primitive.strokeWidth + 1
+		minimum, maximum := primitive.points[0], primitive.points[0]
+		for _, p := range primitive.points {
+			minimum = point{math.Min(minimum.x, p.x), math.Min(minimum.y, p.y)}
+			maximum = point{math.Max(maximum.x, p.x), math.Max(maximum.y, p.y)}
+		}
+		bounds = image.Rect(int(math.Floor(minimum.x-margin)), int(math.Floor(minimum.y-margin)),
+			int(math.Ceil(maximum.x+margin)), int(math.Ceil(maximum.y+margin))).Intersect(img.Bounds())
+		origin = point{float64(bounds.Min.X), float64(bounds.Min.Y)}
+		r.Reset(bounds.Dx(), bounds.Dy())
+	}
+	local := func(p point) (float32, float32) {
+		p = p.sub(origin)
+		return float32(p.x), float32(p.y)
+	}
+	fillPath := func(col color.RGBA) {
+		r.Draw(img, bounds, image.NewUniform(col), image.Point{})
+		r.Reset(bounds.Dx(), bounds.Dy())
+	}
+
+	for _, primitive := range s.primitives {
+		if primitive.kind != "text" {
+			if beginPath(primitive); bounds.Empty() {
+				continue
+			}
+		}
+
+		switch primitive.kind {
+		case "circle":
+			c := primitive.points[0].sub(origin)
+			if primitive.fill != nil {
+				circlePath(r, c, primitive.radius, false)
+				fillPath(*primitive.fill)
+			}
+			if primitive.stroke != nil {
+				half := primitive.strokeWidth / 2
+				circlePath(r, c, primitive.radius+half, false)
+				circlePath(r, c, primitive.radius-half, true)
+				fillPath(*primitive.stroke)
+			}
+		case "polygon":
+			r.MoveTo(local(primitive.points[0]))
+			for _, p := range primitive.points[1:] {
+				r.LineTo(local(p))
+			}
+			r.ClosePath()
+			fillPath(*primitive.fill)
+		case "polyline":
+			half := primitive.strokeWidth / 2
+			for i := 1; i < len(primitive.points); i++ {
+				a, b := primitive.points[i-1], primitive.points[i]
+				offset := b.sub(a).unit().normal().scale(half)
+				r.MoveTo(local(a.add(offset)))
+				r.LineTo(local(b.add(offset)))
+				r.LineTo(local(b.sub(offset)))
+				r.LineTo(local(a.sub(offset)))
+				r.ClosePath()
+			}
+			fillPath(*primitive.stroke)
+		case "text":
+			p := primitive.points[0]
+			drawer := &font.Drawer{Dst: img, Src: image.NewUniform(*primitive.fill), Face: face}
+			textWidth := float64(drawer.MeasureString(primitive.text)) / 64
+			drawer.Dot = fixed.P(int(p.x-textWidth/2), int(p.y+4*k))
+			drawer.DrawString(primitive.text)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	err = png.Encode(buffer, img)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding image to PNG: %v", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func svgColor(c *color.RGBA) string {
+	if c == nil {
+		return "none"
+	}
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func svgPoints(points []point) string {
+	coordinates := make([]string, len(points))
+	for i, p := range points {
+		coordinates[i] = fmt.Sprintf("%.2f,%.2f", p.x, p.y)
+	}
+	return strings.Join(coordinates, " ")
+}
+
+func (s *drawingScene) svg() []byte {
+	var buffer bytes.Buffer
+
+	fmt.Fprintf(&buffer, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.2f %.2f">`+"\n",
+		math.Ceil(s.width), math.Ceil(s.height), s.width, s.height)
+	fmt.Fprintf(&buffer, `  <rect width="100%%" height="100%%" fill="%s"/>`+"\n", svgColor(&whiteColor))
+
+	for _, primitive := range s.primitives {
+		switch primitive.kind {
+		case "circle":
+			c := primitive.points[0]
+			fmt.Fprintf(&buffer, `  <circle cx="%.2f" cy="%.2f" r="%.2f" fill="%s" stroke="%s" stroke-width="%.2f"/>`+"\n",
+				c.x, c.y, primitive.radius, svgColor(primitive.fill), svgColor(primitive.stroke), primitive.strokeWidth)
+		case "polygon":
+			fmt.Fprintf(&buffer, `  <polygon points="%s" fill="%s"/>`+"\n",
+				svgPoints(primitive.points), svgColor(primitive.fill))
+		case "polyline":
+			fmt.Fprintf(&buffer, `  <polyline points="%s" fill="none" stroke="%s" stroke-width="%.2f"/>`+"\n",
+				svgPoints(primitive.points), svgColor(primitive.stroke), primitive.strokeWidth)
+		case "text":
+			var text bytes.Buffer
+			xml.EscapeText(&text, []byte(primitive.text))
+			p := primitive.points[0]
+			fmt.Fprintf(&buffer, `  <text x="%.2f" y="%.2f" fill="%s" font-family="monospace" font-size="13" text-anchor="middle" dominant-baseline="central">%s</text>`+"\n",
+				p.x, p.y, svgColor(primitive.fill), text.String())
+		}
+	}
+
+	buffer.WriteString("</svg>\n")
+
+	return buffer.Bytes()
+}
+
+func pdfColor(c *color.RGBA) string {
+	return fmt.Sprintf("%.3f %.3f %.3f", float64(c.R)/255, float64(c.G)/255, float64(c.B)/255)
+}
+
+type GlyphError struct {
+	Char rune
+}
+
+func (e *GlyphError) Error() string {
+	return fmt.Sprintf("character %q cannot be rendered in PDF output", e.Char)
+}
+
+// pdfFont embeds Go Mono as a CID-keyed font with Identity-H encoding, so any
+// character the font covers, Cyrillic included, keeps its glyph and stays
+// searchable through the ToUnicode map.
+type pdfFont struct {
+	font   *sfnt.Font
+	buffer sfnt.Buffer
+	glyphs map[sfnt.GlyphIndex]rune
+}
+
+func newPDFFont() (*pdfFont, error) {
+	mono, err := sfnt.Parse(gomono.TTF)
+	if err != nil {
+		return nil, fmt.Errorf("error loading font: %v", err)
+	}
+
+	return &pdfFont{font: mono, glyphs: make(map[sfnt.GlyphIndex]rune)}, nil
+}
+
+func (f *pdfFont) encode(text string) (string, error) {
+	var encoded strings.Builder
+	for _, char := range text {
+		glyph, err := f.font.GlyphIndex(&f.buffer, char)
+		if err != nil {
+			return "", err
+		}
+		if glyph == 0 {
+			return "", &GlyphError{Char: char}
+		}
+
+		f.glyphs[glyph] = char
+		fmt.Fprintf(&encoded, "%04X", uint16(glyph))
+	}
+	return encoded.String(), nil
+}
+
+func (f *pdfFont) toUnicode() string {
+	glyphs := make([]sfnt.GlyphIndex, 0, len(f.glyphs))
+	for glyph := range f.glyphs {
+		glyphs = append(glyphs, glyph)
+	}
+	sort.Slice(glyphs, func(i, j int) bool { return glyphs[i] < glyphs[j] })
+
+	var cmap strings.Builder
+	cmap.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	cmap.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	cmap.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	cmap.WriteString("1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+
+	// A bfchar block may hold at most 100 mappings.
+	for start := 0; start < len(glyphs); start += 100 {
+		end := min(start+100, len(glyphs))
+		fmt.Fprintf(&cmap, "%d beginbfchar\n", end-start)
+		for _, glyph := range glyphs[start:end] {
+			fmt.Fprintf(&cmap, "<%04X> <", uint16(glyph))
+			for _, unit := range utf16.Encode([]rune{f.glyphs[glyph]}) {
+				fmt.Fprintf(&cmap, "%04X", unit)
+			}
+			cmap.WriteString(">\n")
+		}
+		cmap.WriteString("endbfchar\n")
+	}
+
+	cmap.WriteString("endcmap\nCMapName currentdict /defineresource pop\nend\nend\n")
+	return cmap.String()
+}
+
+// objects returns the font dictionaries, numbered from first; the Type0 font
+// that pages refer to comes first.
+func (f *pdfFont) objects(first int) ([]string, error) {
+	const unitsPerEm = 1000
+	ppem := fixed.I(unitsPerEm)
+
+	metrics, err := f.font.Metrics(&f.buffer, ppem, font.HintingNone)
+	if err != nil {
+		return nil, err
+	}
+	bounds, err := f.font.Bounds(&f.buffer, ppem, font.HintingNone)
+	if err != nil {
+		return nil, err
+	}
+	advance, err := f.font.GlyphAdvance(&f.buffer, 0, ppem, font.HintingNone)
+	if err != nil {
+		return nil, err
+	}
+
+	var compressed bytes.Buffer
+	writer := zlib.NewWriter(&compressed)
+	if _, err := writer.Write(gomono.TTF); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	toUnicode := f.toUnicode()
+
+	return []string{
+		fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /GoMono /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+			first+1, first+4),
+		fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /GoMono /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /DW %d /CIDToGIDMap /Identity >>",
+			first+2, advance.Round()),
+		fmt.Sprintf("<< /Type /FontDescriptor /FontName /GoMono /Flags 33 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+			bounds.Min.X.Round(), -bounds.Max.Y.Round(), bounds.Max.X.Round(), -bounds.Min.Y.Round(),
+			metrics.Ascent.Round(), -metrics.Descent.Round(), metrics.CapHeight.Round(), first+3),
+		fmt.Sprintf("<< /Length %d /Length1 %d /Filter /FlateDecode >>\nstream\n%s\nendstream",
+			compressed.Len(), len(gomono.TTF), compressed.String()),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(toUnicode), toUnicode),
+	}, nil
+}
+
+func pdfCirclePath(content *bytes.Buffer, c point, radius float64) {
+	const k = 0.5522847498
+	kr := radius * k
+
+	fmt.Fprintf(content, "%.2f %.2f m\n", c.x+radius, c.y)
+	fmt.Fprintf(content, "%.2f %.2f %.2f %.2f %.2f %.2f c\n", c.x+radius, c.y+kr, c.x+kr, c.y+radius, c.x, c.y+radius)
+	fmt.Fprintf(content, "%.2f %.2f %.2f %.2f %.2f %.2f c\n", c.x-kr, c.y+radius, c.x-radius, c.y+kr, c.x-radius, c.y)
+	fmt.Fprintf(content, "%.2f %.2f %.2f %.2f %.2f %.2f c\n", c.x-radius, c.y-kr, c.x-kr, c.y-radius, c.x, c.y-radius)
+	fmt.Fprintf(content, "%.2f %.2f %.2f %.2f %.2f %.2f c\n", c.x+kr, c.y-radius, c.x+radius, c.y-kr, c.x+radius, c.y)
+	content.WriteString("h\n")
+}
+
+func (s *drawingScene) pdf() ([]byte, error) {
+	const fontSize = 11.0
+
+	pdfFont, err := newPDFFont()
+	if err != nil {
+		return nil, err
+	}
+
+	var content bytes.Buffer
+	fmt.Fprintf(&content, "1 0 0 -1 0 %.2f cm\n", s.height)
+
+	for _, primitive := range s.primitives {
+		switch primitive.kind {
+		case "circle":
+			operator := ""
+			if primitive.fill != nil {
+				fmt.Fprintf(&content, "%s rg\n", pdfColor(primitive.fill))
+				operator = "f"
+			}
+			if primitive.stroke != nil {
+				fmt.Fprintf(&content, "%s RG %.2f w\n", pdfColor(primitive.stroke), primitive.strokeWidth)
+				operator = "S"
+				if primitive.fill != nil {
+					operator = "B"
+				}
+			}
+			pdfCirclePath(&content, primitive.points[0], primitive.radius)
+			content.WriteString(operator + "\n")
+		case "polygon":
+			fmt.Fprintf(&content, "%s rg\n", pdfColor(primitive.fill))
+			fmt.Fprintf(&content, "%.2f %.2f m\n", primitive.points[0].x, primitive.points[0].y)
+			for _, p := range primitive.points[1:] {
+				fmt.Fprintf(&content, "%.2f %.2f l\n", p.x, p.y)
+			}
+			content.WriteString("h f\n")
+		case "polyline":
+			fmt.Fprintf(&content, "%s RG %.2f w\n", pdfColor(primitive.stroke), primitive.strokeWidth)
+			fmt.Fprintf(&content, "%.2f %.2f m\n", primitive.points[0].x, primitive.points[0].y)
+			for _, p := range primitive.points[1:] {
+				fmt.Fprintf(&content, "%.2f %.2f l\n", p.x, p.y)
+			}
+			content.WriteString("S\n")
+		case "text":
+			p := primitive.points[0]
+			text, err := pdfFont.encode(primitive.text)
+			if err != nil {
+				return nil, err
+			}
+			textWidth := float64(len([]rune(primitive.text))) * fontSize * 0.6
+			fmt.Fprintf(&content, "%s rg\n", pdfColor(primitive.fill))
+			fmt.Fprintf(&content, "BT /F1 %.0f Tf 1 0 0 -1 %.2f %.2f Tm <%s> Tj ET\n",
+				fontSize, p.x-textWidth/2, p.y+fontSize*0.35, text)
+		}
+	}
+
+	fontObjects, err := pdfFont.objects(5)
+	if err != nil {
+		return nil, fmt.Errorf("error embedding font: %v", err)
+	}
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 5 0 R >> >> /Contents 4 0 R >>", s.width, s.height),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+	}
+	objects = append(objects, fontObjects...)
+
+	var buffer bytes.Buffer
+	buffer.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buffer.Len()
+		fmt.Fprintf(&buffer, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xrefOffset := buffer.Len()
+	fmt.Fprintf(&buffer, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buffer, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buffer, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xrefOffset)
+
+	return buffer.Bytes(), nil
+}
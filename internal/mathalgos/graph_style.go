@@ -0,0 +1,114 @@
+package mathalgos
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+	"gonum.org/v1/gonum/graph/encoding"
+)
+
+const defaultDPI = 96
+
+func ValidateGraphStyle(style models.GraphStyle) error {
+	switch style.Layout {
+	case "", CircularLayout, LayeredLayout:
+	default:
+		return fmt.Errorf("unknown layout: %q", style.Layout)
+	}
+
+	switch strings.ToUpper(style.Direction) {
+	case "", "TB", "BT", "LR", "RL":
+	default:
+		return fmt.Errorf("unknown direction: %q", style.Direction)
+	}
+
+	if style.DPI < 0 || style.DPI > 600 {
+		return fmt.Errorf("dpi must be between 1 and 600, or 0 for the default of %d, got %d", defaultDPI, style.DPI)
+	}
+
+	for node, nodeColor := range style.NodeColors {
+		if _, ok := lookupColor(nodeColor); !ok {
+			return fmt.Errorf("unknown color %q for node %q", nodeColor, node)
+		}
+	}
+
+	for _, edgeColor := range style.EdgeColors {
+		if _, ok := lookupColor(edgeColor.Color); !ok {
+			return fmt.Errorf("unknown color %q for edge (%s, %s)", edgeColor.Color, edgeColor.Pair[0], edgeColor.Pair[1])
+		}
+	}
+
+	if style.HighlightColor != "" {
+		if _, ok := lookupColor(style.HighlightColor); !ok {
+			return fmt.Errorf("unknown highlight color: %q", style.HighlightColor)
+		}
+	}
+
+	return nil
+}
+
+func styleGraphAttributes(style models.GraphStyle, title, direction string) []encoding.Attribute {
+	if style.Title != nil {
+		title = *style.Title
+	}
+	if style.Direction != "" {
+		direction = strings.ToUpper(style.Direction)
+	}
+
+	attributes := []encoding.Attribute{
+		{Key: "label", Value: title},
+		{Key: "labelloc", Value: "t"},
+	}
+	if direction != "" {
+		attributes = append(attributes, encoding.Attribute{Key: "rankdir", Value: direction})
+	}
+	if style.Layout == CircularLayout {
+		attributes = append(attributes, encoding.Attribute{Key: "layout", Value: "circo"})
+	}
+	if style.DPI > 0 {
+		attributes = append(attributes, encoding.Attribute{Key: "dpi", Value: fmt.Sprint(style.DPI)})
+	}
+
+	return attributes
+}
+
+func styleNodeAttributes(style models.GraphStyle, label string) []encoding.Attribute {
+	fill := "skyblue"
+	if nodeColor, exists := style.NodeColors[label]; exists {
+		fill = nodeColor
+	}
+
+	return []encoding.Attribute{
+		{Key: "label", Value: label},
+		{Key: "color", Value: fill},
+		{Key: "style", Value: "filled"},
+	}
+}
+
+func styleEdgeAttributes(style models.GraphStyle, pair [2]string) []encoding.Attribute {
+	for _, highlighted := range style.HighlightPairs {
+		if highlighted == pair {
+			highlightColor := style.HighlightColor
+			if highlightColor == "" {
+				highlightColor = "red"
+			}
+
+			return []encoding.Attribute{
+				{Key: "color", Value: highlightColor},
+				{Key: "penwidth", Value: "2.5"},
+			}
+		}
+	}
+
+	edgeColor := "black"
+	for _, colored := range style.EdgeColors {
+		if colored.Pair == pair {
+			edgeColor = colored.Color
+		}
+	}
+
+	return []encoding.Attribute{
+		{Key: "color", Value: edgeColor},
+	}
+}
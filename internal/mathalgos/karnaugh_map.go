@@ -0,0 +1,225 @@
+package mathalgos
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	maxKarnaughVariables = 6
+	karnaughCellSize     = 50.0
+	karnaughHeaderSize   = 70.0
+	karnaughGroupInset   = 4.0
+	karnaughGroupWidth   = 2.5
+)
+
+type KarnaughGroup struct {
+	Pattern    string
+	Term       string
+	Color      string
+	Cells      [][2]int
+	Rectangles [][4]int
+}
+
+type KarnaughMap struct {
+	Variables       []string
+	RowVariables    []string
+	ColumnVariables []string
+	RowLabels       []string
+	ColumnLabels    []string
+	Values          [][]int
+	Minterms        [][]int
+	Groups          []KarnaughGroup
+	MinimalDNF      string
+}
+
+func grayCode(i int) int {
+	return i ^ (i >> 1)
+}
+
+func grayLabels(n int) []string {
+	labels := make([]string, 1<<n)
+	for i := range labels {
+		labels[i] = fmt.Sprintf("%0*b", n, grayCode(i))
+		if n == 0 {
+			labels[i] = ""
+		}
+	}
+	return labels
+}
+
+// matchingPositions returns the Gray-ordered positions whose code matches a
+// pattern of 0, 1 and '-' characters.
+func matchingPositions(pattern string) []int {
+	n := len(pattern)
+	positions := []int{}
+	for i := 0; i < 1<<n; i++ {
+		code := grayCode(i)
+		matches := true
+		for j, char := range pattern {
+			bit := (code>>(n-1-j))&1 == 1
+			if (char == '1' && !bit) || (char == '0' && bit) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+// contiguousRuns splits sorted positions into [start, length] runs. Groups that
+// wrap around the map edge come out as separate runs on each side.
+func contiguousRuns(positions []int) [][2]int {
+	runs := [][2]int{}
+	for _, p := range positions {
+		if len(runs) > 0 && runs[len(runs)-1][0]+runs[len(runs)-1][1] == p {
+			runs[len(runs)-1][1]++
+			continue
+		}
+		runs = append(runs, [2]int{p, 1})
+	}
+	return runs
+}
+
+func BuildKarnaughMap(expression string, variables []string) (KarnaughMap, error) {
+	minimization, err := NewLogicSimplifier().MinimizeExpression(expression, variables)
+	if err != nil {
+		return KarnaughMap{}, err
+	}
+
+	n := len(minimization.Variables)
+	if n < 1 || n > maxKarnaughVariables {
+		return KarnaughMap{}, fmt.Errorf("karnaugh maps support 1 to %d variables, got %d", maxKarnaughVariables, n)
+	}
+
+	rowBits := n / 2
+	columnBits := n - rowBits
+
+	kmap := KarnaughMap{
+		Variables:       minimization.Variables,
+		RowVariables:    minimization.Variables[:rowBits],
+		ColumnVariables: minimization.Variables[rowBits:],
+		RowLabels:       grayLabels(rowBits),
+		ColumnLabels:    grayLabels(columnBits),
+		Values:          make([][]int, 1<<rowBits),
+		Minterms:        make([][]int, 1<<rowBits),
+		Groups:          []KarnaughGroup{},
+		MinimalDNF:      minimization.MinimalDNF,
+	}
+
+	ones := make(map[int]bool)
+	for _, minterm := range minimization.Minterms {
+		ones[minterm] = true
+	}
+
+	for r := range kmap.Values {
+		kmap.Values[r] = make([]int, 1<<columnBits)
+		kmap.Minterms[r] = make([]int, 1<<columnBits)
+		for c := range kmap.Values[r] {
+			index := grayCode(r)<<columnBits | grayCode(c)
+			kmap.Minterms[r][c] = index
+			kmap.Values[r][c] = boolToInt(ones[index])
+		}
+	}
+
+	for i, implicant := range minimization.Selected {
+		rows := matchingPositions(implicant.Pattern[:rowBits])
+		columns := matchingPositions(implicant.Pattern[rowBits:])
+
+		group := KarnaughGroup{
+			Pattern: implicant.Pattern,
+			Term:    implicant.Term,
+			Color:   componentPalette[i%len(componentPalette)][1],
+		}
+		for _, r := range rows {
+			for _, c := range columns {
+				group.Cells = append(group.Cells, [2]int{r, c})
+			}
+		}
+		for _, rowRun := range contiguousRuns(rows) {
+			for _, columnRun := range contiguousRuns(columns) {
+				group.Rectangles = append(group.Rectangles, [4]int{rowRun[0], columnRun[0], rowRun[1], columnRun[1]})
+			}
+		}
+
+		kmap.Groups = append(kmap.Groups, group)
+	}
+
+	return kmap, nil
+}
+
+func (k KarnaughMap) buildScene() *drawingScene {
+	rows, columns := len(k.Values), len(k.Values[0])
+	left, top := karnaughHeaderSize, karnaughHeaderSize+titleHeight
+
+	scene := &drawingScene{
+		width:  left + float64(columns)*karnaughCellSize + drawingMargin/2,
+		height: top + float64(rows)*karnaughCellSize + drawingMargin/2,
+	}
+	scene.width = math.Max(scene.width, float64(len([]rune(k.imageTitle())))*fontWidth+drawingMargin)
+
+	for r := 0; r <= rows; r++ {
+		y := top + float64(r)*karnaughCellSize
+		scene.addLine([]point{{left, y}, {left + float64(columns)*karnaughCellSize, y}}, blackColor, 1)
+	}
+	for c := 0; c <= columns; c++ {
+		x := left + float64(c)*karnaughCellSize
+		scene.addLine([]point{{x, top}, {x, top + float64(rows)*karnaughCellSize}}, blackColor, 1)
+	}
+	scene.addLine([]point{{left - karnaughHeaderSize, top - karnaughHeaderSize}, {left, top}}, blackColor, 1)
+
+	scene.addText(point{left - karnaughHeaderSize*3/4, top - karnaughHeaderSize/4}, strings.Join(k.RowVariables, ""), blackColor)
+	scene.addText(point{left - karnaughHeaderSize/4, top - karnaughHeaderSize*3/4}, strings.Join(k.ColumnVariables, ""), blackColor)
+
+	for r, label := range k.RowLabels {
+		scene.addText(point{left - karnaughHeaderSize/4, top + (float64(r)+0.5)*karnaughCellSize}, label, blackColor)
+	}
+	for c, label := range k.ColumnLabels {
+		scene.addText(point{left + (float64(c)+0.5)*karnaughCellSize, top - karnaughHeaderSize/4}, label, blackColor)
+	}
+
+	for r, row := range k.Values {
+		for c, value := range row {
+			center := point{left + (float64(c)+0.5)*karnaughCellSize, top + (float64(r)+0.5)*karnaughCellSize}
+			scene.addText(center, fmt.Sprint(value), blackColor)
+		}
+	}
+
+	for i, group := range k.Groups {
+		groupColor := parseColor(group.Color, blackColor)
+		inset := karnaughGroupInset + float64(i%4)*karnaughGroupWidth
+		for _, rectangle := range group.Rectangles {
+			x0 := left + float64(rectangle[1])*karnaughCellSize + inset
+			y0 := top + float64(rectangle[0])*karnaughCellSize + inset
+			x1 := left + float64(rectangle[1]+rectangle[3])*karnaughCellSize - inset
+			y1 := top + float64(rectangle[0]+rectangle[2])*karnaughCellSize - inset
+			scene.addLine([]point{{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}, {x0, y0}}, groupColor, karnaughGroupWidth)
+		}
+	}
+
+	scene.addText(point{scene.width / 2, titleHeight / 2}, k.imageTitle(), blackColor)
+
+	return scene
+}
+
+// imageTitle writes the cover in sum-of-products notation, since Go Mono, the
+// image font, has no glyphs for ∧ and ∨.
+func (k KarnaughMap) imageTitle() string {
+	if len(k.Groups) == 0 {
+		return k.MinimalDNF
+	}
+
+	products := make([]string, len(k.Groups))
+	for i, group := range k.Groups {
+		products[i] = strings.ReplaceAll(group.Term, " ∧ ", "·")
+	}
+	return strings.Join(products, " + ")
+}
+
+func (k KarnaughMap) Render(format string) ([]byte, error) {
+	return k.buildScene().render(format)
+}
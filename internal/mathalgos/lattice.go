@@ -0,0 +1,183 @@
+package mathalgos
+
+import (
+	"fmt"
+
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+)
+
+type LatticeAnalysis struct {
+	Elements        []string
+	JoinTable       [][]*string
+	MeetTable       [][]*string
+	Complements     map[string][]string
+	IsLattice       bool
+	IsDistributive  bool
+	IsModular       bool
+	IsComplemented  bool
+	IsBoolean       bool
+	LeastElement    *string
+	GreatestElement *string
+}
+
+type poset struct {
+	elements []string
+	relation map[[2]string]struct{}
+}
+
+func (p *poset) lessOrEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	_, exists := p.relation[[2]string{a, b}]
+	return exists
+}
+
+func (p *poset) supremum(a, b string) (string, bool) {
+	upperBounds := []string{}
+	for _, c := range p.elements {
+		if p.lessOrEqual(a, c) && p.lessOrEqual(b, c) {
+			upperBounds = append(upperBounds, c)
+		}
+	}
+
+	return p.leastOf(upperBounds)
+}
+
+func (p *poset) infimum(a, b string) (string, bool) {
+	lowerBounds := []string{}
+	for _, c := range p.elements {
+		if p.lessOrEqual(c, a) && p.lessOrEqual(c, b) {
+			lowerBounds = append(lowerBounds, c)
+		}
+	}
+
+	return p.greatestOf(lowerBounds)
+}
+
+func (p *poset) leastOf(candidates []string) (string, bool) {
+	for _, c := range candidates {
+		isLeast := true
+		for _, other := range candidates {
+			if !p.lessOrEqual(c, other) {
+				isLeast = false
+				break
+			}
+		}
+		if isLeast {
+			return c, true
+		}
+	}
+
+	return "", false
+}
+
+func (p *poset) greatestOf(candidates []string) (string, bool) {
+	for _, c := range candidates {
+		isGreatest := true
+		for _, other := range candidates {
+			if !p.lessOrEqual(other, c) {
+				isGreatest = false
+				break
+			}
+		}
+		if isGreatest {
+			return c, true
+		}
+	}
+
+	return "", false
+}
+
+func AnalyzeLattice(model models.BinaryRelationModel) (LatticeAnalysis, error) {
+	order := AnalyzePartialOrder(model)
+	if !order.IsPartialOrder && !order.IsStrictPartialOrder {
+		return LatticeAnalysis{}, fmt.Errorf("relation is not a partial order")
+	}
+
+	p := &poset{
+		elements: getRelationElements(model),
+		relation: getRelationSet(model),
+	}
+
+	n := len(p.elements)
+	joins := make(map[[2]string]string)
+	meets := make(map[[2]string]string)
+
+	analysis := LatticeAnalysis{
+		Elements:        p.elements,
+		JoinTable:       make([][]*string, n),
+		MeetTable:       make([][]*string, n),
+		IsLattice:       n > 0,
+		LeastElement:    order.LeastElement,
+		GreatestElement: order.GreatestElement,
+	}
+
+	for i, a := range p.elements {
+		analysis.JoinTable[i] = make([]*string, n)
+		analysis.MeetTable[i] = make([]*string, n)
+
+		for j, b := range p.elements {
+			if join, exists := p.supremum(a, b); exists {
+				joins[[2]string{a, b}] = join
+				analysis.JoinTable[i][j] = &join
+			} else {
+				analysis.IsLattice = false
+			}
+
+			if meet, exists := p.infimum(a, b); exists {
+				meets[[2]string{a, b}] = meet
+				analysis.MeetTable[i][j] = &meet
+			} else {
+				analysis.IsLattice = false
+			}
+		}
+	}
+
+	if !analysis.IsLattice {
+		return analysis, nil
+	}
+
+	join := func(a, b string) string { return joins[[2]string{a, b}] }
+	meet := func(a, b string) string { return meets[[2]string{a, b}] }
+
+	analysis.IsDistributive = true
+	analysis.IsModular = true
+
+	for _, a := range p.elements {
+		for _, b := range p.elements {
+			for _, c := range p.elements {
+				if meet(a, join(b, c)) != join(meet(a, b), meet(a, c)) {
+					analysis.IsDistributive = false
+				}
+				if p.lessOrEqual(a, c) && join(a, meet(b, c)) != meet(join(a, b), c) {
+					analysis.IsModular = false
+				}
+			}
+		}
+	}
+
+	bottom := *analysis.LeastElement
+	top := *analysis.GreatestElement
+
+	analysis.Complements = make(map[string][]string)
+	analysis.IsComplemented = true
+
+	for _, a := range p.elements {
+		complements := []string{}
+		for _, b := range p.elements {
+			if join(a, b) == top && meet(a, b) == bottom {
+				complements = append(complements, b)
+			}
+		}
+
+		analysis.Complements[a] = complements
+		if len(complements) == 0 {
+			analysis.IsComplemented = false
+		}
+	}
+
+	analysis.IsBoolean = analysis.IsDistributive && analysis.IsComplemented
+
+	return analysis, nil
+}
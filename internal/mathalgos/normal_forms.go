@@ -0,0 +1,108 @@
+package mathalgos
+
+import (
+	"strings"
+	"unicode"
+)
+
+type PerfectNormalForms struct {
+	Variables []string
+	Minterms  []int
+	Maxterms  []int
+	SDNF      string
+	SKNF      string
+	SDNFLatex string
+	SKNFLatex string
+}
+
+type normalFormNotation struct {
+	and, or  string
+	negate   func(string) string
+	variable func(string) string
+}
+
+var unicodeNotation = normalFormNotation{
+	and:      " ∧ ",
+	or:       " ∨ ",
+	negate:   func(v string) string { return "¬" + v },
+	variable: func(v string) string { return v },
+}
+
+var latexNotation = normalFormNotation{
+	and:      " \\land ",
+	or:       " \\lor ",
+	negate:   func(v string) string { return "\\overline{" + v + "}" },
+	variable: latexVariable,
+}
+
+// latexVariable writes trailing digits as a subscript, so x10 becomes x_{10}.
+func latexVariable(name string) string {
+	end := len(name)
+	for end > 0 && unicode.IsDigit(rune(name[end-1])) {
+		end--
+	}
+	if end == 0 || end == len(name) {
+		return name
+	}
+	return name[:end] + "_{" + name[end:] + "}"
+}
+
+// formatNormalForm joins one clause per row index. Conjunctive clauses negate
+// variables that are 1 in the row, disjunctive clauses those that are 0.
+func formatNormalForm(variables []string, indices []int, conjunctive bool, notation normalFormNotation) string {
+	inner, outer, empty, full := notation.and, notation.or, "0", "1"
+	if conjunctive {
+		inner, outer, empty, full = notation.or, notation.and, "1", "0"
+	}
+
+	if len(indices) == 0 {
+		return empty
+	}
+	if len(variables) == 0 {
+		return full
+	}
+
+	clauses := make([]string, len(indices))
+	for i, index := range indices {
+		literals := make([]string, len(variables))
+		for j, variable := range variables {
+			bit := (index>>(len(variables)-1-j))&1 == 1
+			literal := notation.variable(variable)
+			if bit == conjunctive {
+				literal = notation.negate(literal)
+			}
+			literals[j] = literal
+		}
+
+		clause := strings.Join(literals, inner)
+		if len(literals) > 1 && len(indices) > 1 {
+			clause = "(" + clause + ")"
+		}
+		clauses[i] = clause
+	}
+
+	return strings.Join(clauses, outer)
+}
+
+func BuildPerfectNormalForms(table TruthTable) PerfectNormalForms {
+	forms := PerfectNormalForms{
+		Variables: table.Variables,
+		Minterms:  []int{},
+		Maxterms:  []int{},
+	}
+
+	for i, value := range table.Result {
+		if value == 1 {
+			forms.Minterms = append(forms.Minterms, i)
+		} else {
+			forms.Maxterms = append(forms.Maxterms, i)
+		}
+	}
+
+	forms.SDNF = formatNormalForm(table.Variables, forms.Minterms, false, unicodeNotation)
+	forms.SKNF = formatNormalForm(table.Variables, forms.Maxterms, true, unicodeNotation)
+	forms.SDNFLatex = formatNormalForm(table.Variables, forms.Minterms, false, latexNotation)
+	forms.SKNFLatex = formatNormalForm(table.Variables, forms.Maxterms, true, latexNotation)
+
+	return forms
+}
@@ -0,0 +1,203 @@
+package mathalgos
+
+import (
+	"fmt"
+
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+	"gonum.org/v1/gonum/graph/encoding"
+	"gonum.org/v1/gonum/graph/encoding/dot"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+type PartialOrderAnalysis struct {
+	IsPartialOrder       bool
+	IsStrictPartialOrder bool
+	IsTotalOrder         bool
+	IsStrictTotalOrder   bool
+	MinimalElements      []string
+	MaximalElements      []string
+	LeastElement         *string
+	GreatestElement      *string
+	CoverRelation        [][2]string
+}
+
+type HasseDiagram struct {
+	*simple.DirectedGraph
+	style models.GraphStyle
+}
+
+func (h *HasseDiagram) DOTID() string {
+	return "HasseDiagram"
+}
+
+func (h *HasseDiagram) DOTAttributers() (graph, node, edge encoding.Attributer) {
+	graphAttributes := encoding.Attributes(styleGraphAttributes(h.style, "Hasse Diagram", "BT"))
+	return &graphAttributes,
+		&encoding.Attributes{},
+		&encoding.Attributes{
+			{Key: "arrowhead", Value: "none"},
+		}
+}
+
+func AnalyzePartialOrder(model models.BinaryRelationModel) PartialOrderAnalysis {
+	elements := getRelationElements(model)
+	relation := getRelationSet(model)
+
+	reflexiveProperties := checkReflexiveProperty(elements, relation)
+	symmetryProperties := checkSymmetryProperties(elements, relation)
+	transitivityProperties := checkTransitivityProperties(elements, relation)
+
+	analysis := PartialOrderAnalysis{
+		IsPartialOrder: reflexiveProperties.Holds("reflexive") &&
+			symmetryProperties.Holds("antisymmetric") &&
+			transitivityProperties.Holds("transitive"),
+		IsStrictPartialOrder: reflexiveProperties.Holds("antireflexive") &&
+			transitivityProperties.Holds("transitive"),
+	}
+
+	if !analysis.IsPartialOrder && !analysis.IsStrictPartialOrder {
+		return analysis
+	}
+
+	isComparable := true
+	for i, a := range elements {
+		for _, b := range elements[i+1:] {
+			_, ab := relation[[2]string{a, b}]
+			_, ba := relation[[2]string{b, a}]
+			if !ab && !ba {
+				isComparable = false
+			}
+		}
+	}
+	analysis.IsTotalOrder = analysis.IsPartialOrder && isComparable
+	analysis.IsStrictTotalOrder = analysis.IsStrictPartialOrder && isComparable
+
+	analysis.MinimalElements = []string{}
+	analysis.MaximalElements = []string{}
+
+	for _, a := range elements {
+		isMinimal, isMaximal := true, true
+		isLeast, isGreatest := true, true
+
+		for _, b := range elements {
+			if a == b {
+				continue
+			}
+
+			_, ab := relation[[2]string{a, b}]
+			_, ba := relation[[2]string{b, a}]
+
+			if ba {
+				isMinimal = false
+			}
+			if ab {
+				isMaximal = false
+			}
+			if !ab {
+				isLeast = false
+			}
+			if !ba {
+				isGreatest = false
+			}
+		}
+
+		if isMinimal {
+			analysis.MinimalElements = append(analysis.MinimalElements, a)
+		}
+		if isMaximal {
+			analysis.MaximalElements = append(analysis.MaximalElements, a)
+		}
+		if isLeast {
+			least := a
+			analysis.LeastElement = &least
+		}
+		if isGreatest {
+			greatest := a
+			analysis.GreatestElement = &greatest
+		}
+	}
+
+	analysis.CoverRelation = getCoverRelation(elements, relation)
+
+	return analysis
+}
+
+func getCoverRelation(elements []string, relation map[[2]string]struct{}) [][2]string {
+	cover := make(map[[2]string]struct{})
+
+	for pair := range relation {
+		a, b := pair[0], pair[1]
+		if a == b {
+			continue
+		}
+
+		isCover := true
+		for _, c := range elements {
+			if c == a || c == b {
+				continue
+			}
+
+			_, ac := relation[[2]string{a, c}]
+			_, cb := relation[[2]string{c, b}]
+			if ac && cb {
+				isCover = false
+				break
+			}
+		}
+
+		if isCover {
+			cover[pair] = struct{}{}
+		}
+	}
+
+	return sortRelationPairs(elements, cover)
+}
+
+func NewHasseDiagram(model models.BinaryRelationModel, style models.GraphStyle) (*HasseDiagram, error) {
+	analysis := AnalyzePartialOrder(model)
+	if !analysis.IsPartialOrder && !analysis.IsStrictPartialOrder {
+		return nil, fmt.Errorf("relation is not a partial order")
+	}
+
+	graph := simple.NewDirectedGraph()
+	elements := make(map[string]int64)
+
+	for i, element := range getRelationElements(model) {
+		node := relationNode{id: int64(i), label: element, attributes: styleNodeAttributes(style, element)}
+		graph.AddNode(node)
+		elements[element] = node.ID()
+	}
+
+	for i, pair := range analysis.CoverRelation {
+		graph.SetEdge(relationLine{
+			from:       graph.Node(elements[pair[0]]),
+			to:         graph.Node(elements[pair[1]]),
+			id:         int64(i),
+			attributes: styleEdgeAttributes(style, pair),
+		})
+	}
+
+	return &HasseDiagram{DirectedGraph: graph, style: style}, nil
+}
+
+func (h *HasseDiagram) MarshalDOT() ([]byte, error) {
+	dotData, err := dot.Marshal(h, "", "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal graph to DOT: %v", err)
+	}
+
+	return dotData, nil
+}
+
+func (h *HasseDiagram) Render(format, renderer string) ([]byte, error) {
+	layout := h.style.Layout
+	if layout == "" {
+		layout = LayeredLayout
+	}
+
+	return renderGraph(h, layout, format, renderer)
+}
+
+func (h *HasseDiagram) GenerateImage(renderer string) ([]byte, error) {
+	return h.Render("png", renderer)
+}
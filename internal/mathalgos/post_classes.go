@@ -0,0 +1,133 @@
+package mathalgos
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+const maxPostFunctions = 16
+
+var postClassNames = []string{"T0", "T1", "S", "M", "L"}
+
+type PostClassMembership struct {
+	Function       string
+	Variables      []string
+	FunctionVector string
+	T0             bool
+	T1             bool
+	S              bool
+	M              bool
+	L              bool
+}
+
+// PostAnalysis reports MinimalSubsystem as 0-based indices into the table.
+type PostAnalysis struct {
+	Table             []PostClassMembership
+	IsComplete        bool
+	ContainingClasses []string
+	MinimalSubsystem  []int
+}
+
+func (m PostClassMembership) classes() []bool {
+	return []bool{m.T0, m.T1, m.S, m.M, m.L}
+}
+
+func isSelfDual(values []int) bool {
+	last := len(values) - 1
+	for i := range values {
+		if values[i] == values[last-i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isMonotone(values []int) bool {
+	for i := range values {
+		for bit := 1; bit < len(values); bit <<= 1 {
+			if i&bit == 0 && values[i] > values[i|bit] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isLinear checks that no Zhegalkin coefficient belongs to a monomial of
+// degree two or more.
+func isLinear(values []int) bool {
+	for index, coefficient := range mobiusTransform(values) {
+		if coefficient == 1 && bits.OnesCount(uint(index)) > 1 {
+			return false
+		}
+	}
+	return true
+}
+
+func ClassifyPostFunction(label string, variables []string, values []int) PostClassMembership {
+	return PostClassMembership{
+		Function:       label,
+		Variables:      variables,
+		FunctionVector: functionVector(values),
+		T0:             values[0] == 0,
+		T1:             values[len(values)-1] == 1,
+		S:              isSelfDual(values),
+		M:              isMonotone(values),
+		L:              isLinear(values),
+	}
+}
+
+// containingClasses lists the Post classes that contain every function of the
+// subsystem given as a bit mask; by Post's theorem it is complete when empty.
+func containingClasses(table []PostClassMembership, subsystem int) []string {
+	contained := []string{}
+	for c, name := range postClassNames {
+		all := true
+		for i, membership := range table {
+			if subsystem&(1<<i) != 0 && !membership.classes()[c] {
+				all = false
+				break
+			}
+		}
+		if all {
+			contained = append(contained, name)
+		}
+	}
+	return contained
+}
+
+func AnalyzePostClasses(table []PostClassMembership) (PostAnalysis, error) {
+	if len(table) == 0 {
+		return PostAnalysis{}, fmt.Errorf("at least one function is required")
+	}
+	if len(table) > maxPostFunctions {
+		return PostAnalysis{}, fmt.Errorf("at most %d functions are supported, got %d", maxPostFunctions, len(table))
+	}
+
+	all := 1<<len(table) - 1
+	analysis := PostAnalysis{
+		Table:             table,
+		ContainingClasses: containingClasses(table, all),
+		MinimalSubsystem:  []int{},
+	}
+	analysis.IsComplete = len(analysis.ContainingClasses) == 0
+
+	if !analysis.IsComplete {
+		return analysis, nil
+	}
+
+	best := all
+	for subsystem := 1; subsystem <= all; subsystem++ {
+		if bits.OnesCount(uint(subsystem)) < bits.OnesCount(uint(best)) && len(containingClasses(table, subsystem)) == 0 {
+			best = subsystem
+		}
+	}
+
+	for i := range table {
+		if best&(1<<i) != 0 {
+			analysis.MinimalSubsystem = append(analysis.MinimalSubsystem, i)
+		}
+	}
+
+	return analysis, nil
+}
@@ -0,0 +1,57 @@
+package mathalgos
+
+import (
+	"fmt"
+
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+)
+
+// ApplyRelationOperation combines first (R) with second (S) over the union of
+// their elements. "compose" reads left to right, first R, then S: it relates
+// a to c when a R b and b S c for some b, which is the Boolean product of
+// M(R) and M(S).
+func ApplyRelationOperation(operation string, first models.BinaryRelationModel, second *models.BinaryRelationModel, power int) (models.BinaryRelationModel, error) {
+	elements := getRelationElements(first)
+	relation := getRelationSet(first)
+
+	var other map[[2]string]struct{}
+	switch operation {
+	case "compose", "union", "intersection", "difference":
+		if second == nil {
+			return models.BinaryRelationModel{}, fmt.Errorf("operation %q requires a second relation", operation)
+		}
+		elements = mergeUnique(elements, getRelationElements(*second))
+		other = getRelationSet(*second)
+	}
+
+	matrix := RelationToMatrix(elements, relation)
+	var result BooleanMatrix
+
+	switch operation {
+	case "compose":
+		result = matrix.Multiply(RelationToMatrix(elements, other))
+	case "inverse":
+		result = matrix.Transpose()
+	case "complement":
+		result = matrix.Not()
+	case "union":
+		result = matrix.Or(RelationToMatrix(elements, other))
+	case "intersection":
+		result = matrix.And(RelationToMatrix(elements, other))
+	case "difference":
+		result = matrix.And(RelationToMatrix(elements, other).Not())
+	case "power":
+		if power < 0 {
+			return models.BinaryRelationModel{}, fmt.Errorf("power must be non-negative, got %d", power)
+		}
+		result = matrix.Power(power)
+	default:
+		return models.BinaryRelationModel{}, fmt.Errorf("unknown operation: %q", operation)
+	}
+
+	return models.BinaryRelationModel{
+		SetOfElements:  elements,
+		BinaryRelation: sortRelationPairs(elements, result.ToRelation(elements)),
+		Matrix:         result.Ints(),
+	}, nil
+}
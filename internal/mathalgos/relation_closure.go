@@ -0,0 +1,55 @@
+package mathalgos
+
+import (
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+)
+
+type RelationClosure struct {
+	Relation   [][2]string
+	AddedPairs [][2]string
+	Matrix     [][]int
+}
+
+type RelationClosures struct {
+	Reflexive   RelationClosure
+	Symmetric   RelationClosure
+	Transitive  RelationClosure
+	Equivalence RelationClosure
+}
+
+func newRelationClosure(elements []string, original map[[2]string]struct{}, matrix BooleanMatrix) RelationClosure {
+	closure := matrix.ToRelation(elements)
+
+	added := make(map[[2]string]struct{})
+	for pair := range closure {
+		if _, exists := original[pair]; !exists {
+			added[pair] = struct{}{}
+		}
+	}
+
+	return RelationClosure{
+		Relation:   sortRelationPairs(elements, closure),
+		AddedPairs: sortRelationPairs(elements, added),
+		Matrix:     matrix.Ints(),
+	}
+}
+
+func GetRelationClosures(model models.BinaryRelationModel) RelationClosures {
+	elements := getRelationElements(model)
+	relation := getRelationSet(model)
+
+	matrix := RelationToMatrix(elements, relation)
+	identity := IdentityMatrix(len(elements))
+
+	reflexive := matrix.Or(identity)
+	symmetric := matrix.Or(matrix.Transpose())
+	transitive := matrix.Warshall()
+	equivalence := reflexive.Or(reflexive.Transpose()).Warshall()
+
+	return RelationClosures{
+		Reflexive:   newRelationClosure(elements, relation, reflexive),
+		Symmetric:   newRelationClosure(elements, relation, symmetric),
+		Transitive:  newRelationClosure(elements, relation, transitive),
+		Equivalence: newRelationClosure(elements, relation, equivalence),
+	}
+}
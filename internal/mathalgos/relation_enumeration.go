@@ -0,0 +1,283 @@
+package mathalgos
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	maxEnumerationSize      = 6
+	maxEnumerationSteps     = 1 << 22
+	defaultEnumerationLimit = 100
+	maxEnumerationLimit     = 10000
+)
+
+var propertyGroups = map[string]string{
+	"reflexive":      "reflexivity",
+	"antireflexive":  "reflexivity",
+	"nonreflexive":   "reflexivity",
+	"symmetric":      "symmetry",
+	"asymmetric":     "symmetry",
+	"antisymmetric":  "symmetry",
+	"nonsymmetric":   "symmetry",
+	"transitive":     "transitivity",
+	"antitransitive": "transitivity",
+	"nontransitive":  "transitivity",
+}
+
+var compositeProperties = map[string][]string{
+	"equivalence":   {"reflexive", "symmetric", "transitive"},
+	"partial_order": {"reflexive", "antisymmetric", "transitive"},
+}
+
+// leafProperties are the keys the search cannot enforce cell by cell, so
+// they are checked on each complete relation.
+var leafProperties = map[string]struct{}{
+	"nonreflexive":  {},
+	"nonsymmetric":  {},
+	"nontransitive": {},
+}
+
+type RelationEnumeration struct {
+	Elements   []string
+	Properties []string
+	Count      int
+	Relations  [][][2]string
+	Truncated  bool
+}
+
+func expandRelationProperties(properties []string) ([]string, error) {
+	expanded := []string{}
+	seen := make(map[string]struct{})
+
+	add := func(key string) {
+		if _, exists := seen[key]; !exists {
+			seen[key] = struct{}{}
+			expanded = append(expanded, key)
+		}
+	}
+
+	for _, property := range properties {
+		if parts, exists := compositeProperties[property]; exists {
+			for _, part := range parts {
+				add(part)
+			}
+			continue
+		}
+
+		if _, exists := propertyGroups[property]; !exists {
+			return nil, fmt.Errorf("unknown property: %q", property)
+		}
+		add(property)
+	}
+
+	return expanded, nil
+}
+
+// relationSearch fills the matrix one element at a time: first the loop on k,
+// then both cells of every pair {i, k} with i < k. Each cell only takes the
+// values its properties allow, and a triple is checked for (anti)transitivity
+// as soon as all three of its cells are decided.
+type relationSearch struct {
+	n              int
+	matrix         BooleanMatrix
+	decided        BooleanMatrix
+	cells          [][2]int
+	diagonalStates [][2]bool
+	pairStates     [][2]bool
+	transitive     bool
+	antitransitive bool
+	steps          int
+	done           bool
+	visit          func()
+}
+
+func (s *relationSearch) violates(a, b, c int) bool {
+	if !s.decided[a][b] || !s.decided[b][c] || !s.decided[a][c] {
+		return false
+	}
+	if !s.matrix[a][b] || !s.matrix[b][c] {
+		return false
+	}
+	return (s.transitive && !s.matrix[a][c]) || (s.antitransitive && s.matrix[a][c])
+}
+
+func (s *relationSearch) consistent(x, y int) bool {
+	if !s.transitive && !s.antitransitive {
+		return true
+	}
+	for z := 0; z < s.n; z++ {
+		if s.violates(x, y, z) || s.violates(z, x, y) || s.violates(x, z, y) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *relationSearch) run(step int) error {
+	if s.done {
+		return nil
+	}
+
+	s.steps++
+	if s.steps > maxEnumerationSteps {
+		return fmt.Errorf("search exceeded %d steps, add constraints or reduce n", maxEnumerationSteps)
+	}
+
+	if step == len(s.cells) {
+		s.visit()
+		return nil
+	}
+
+	i, k := s.cells[step][0], s.cells[step][1]
+	states := s.pairStates
+	if i == k {
+		states = s.diagonalStates
+	}
+
+	s.decided[i][k], s.decided[k][i] = true, true
+	for _, state := range states {
+		s.matrix[i][k], s.matrix[k][i] = state[0], state[1]
+		if s.consistent(i, k) && s.consistent(k, i) {
+			if err := s.run(step + 1); err != nil {
+				return err
+			}
+		}
+	}
+	s.decided[i][k], s.decided[k][i] = false, false
+	s.matrix[i][k], s.matrix[k][i] = false, false
+
+	return nil
+}
+
+// EnumerateRelations counts, and optionally lists, the relations on
+// {1, ..., n} with the given properties. Counts are exact: property sets
+// without transitivity or "non" keys are counted in closed form, and any other
+// search is limited to maxEnumerationSteps, which fails with an error rather
+// than returning a partial count.
+func EnumerateRelations(n int, properties []string, enumerate bool, limit int) (RelationEnumeration, error) {
+	if n < 0 || n > maxEnumerationSize {
+		return RelationEnumeration{}, fmt.Errorf("n must be between 0 and %d, got %d", maxEnumerationSize, n)
+	}
+	if limit < 0 || limit > maxEnumerationLimit {
+		return RelationEnumeration{}, fmt.Errorf("limit must be between 0 and %d, got %d", maxEnumerationLimit, limit)
+	}
+	if limit == 0 {
+		limit = defaultEnumerationLimit
+	}
+
+	keys, err := expandRelationProperties(properties)
+	if err != nil {
+		return RelationEnumeration{}, err
+	}
+
+	required := make(map[string]struct{})
+	for _, key := range keys {
+		required[key] = struct{}{}
+	}
+	has := func(key string) bool {
+		_, exists := required[key]
+		return exists
+	}
+
+	elements := make([]string, n)
+	for i := range elements {
+		elements[i] = strconv.Itoa(i + 1)
+	}
+
+	result := RelationEnumeration{
+		Elements:   elements,
+		Properties: keys,
+		Relations:  [][][2]string{},
+	}
+
+	search := &relationSearch{
+		n:              n,
+		matrix:         NewBooleanMatrix(n),
+		decided:        NewBooleanMatrix(n),
+		transitive:     has("transitive"),
+		antitransitive: has("antitransitive"),
+	}
+	for k := 0; k < n; k++ {
+		for i := 0; i <= k; i++ {
+			search.cells = append(search.cells, [2]int{i, k})
+		}
+	}
+
+	for _, loop := range []bool{false, true} {
+		if (loop && (has("antireflexive") || has("asymmetric"))) || (!loop && has("reflexive")) {
+			continue
+		}
+		search.diagonalStates = append(search.diagonalStates, [2]bool{loop, loop})
+	}
+	for _, state := range [][2]bool{{false, false}, {true, false}, {false, true}, {true, true}} {
+		oneWay := state[0] != state[1]
+		both := state[0] && state[1]
+		if (oneWay && has("symmetric")) || (both && (has("antisymmetric") || has("asymmetric"))) {
+			continue
+		}
+		search.pairStates = append(search.pairStates, state)
+	}
+
+	// Without transitivity or "non" constraints every cell is chosen
+	// independently, so the count has a closed form and the search only has
+	// to produce the relations that are returned.
+	leafKeys := []string{}
+	for _, key := range keys {
+		if _, exists := leafProperties[key]; exists {
+			leafKeys = append(leafKeys, key)
+		}
+	}
+	independent := !search.transitive && !search.antitransitive
+	closedForm := independent && len(leafKeys) == 0
+	if independent {
+		candidates := power(len(search.diagonalStates), n) * power(len(search.pairStates), n*(n-1)/2)
+		if !closedForm && candidates > maxEnumerationSteps {
+			return RelationEnumeration{}, fmt.Errorf("%d candidate relations exceed the search limit of %d, add constraints or reduce n", candidates, maxEnumerationSteps)
+		}
+
+		if closedForm {
+			result.Count = candidates
+			result.Truncated = enumerate && result.Count > limit
+			if !enumerate || result.Count == 0 {
+				return result, nil
+			}
+		}
+	}
+
+	search.visit = func() {
+		for _, key := range leafKeys {
+			if !search.matrix.HasProperty(key) {
+				return
+			}
+		}
+
+		if !closedForm {
+			result.Count++
+		}
+		if enumerate {
+			if len(result.Relations) < limit {
+				relation := search.matrix.ToRelation(elements)
+				result.Relations = append(result.Relations, sortRelationPairs(elements, relation))
+			} else {
+				result.Truncated = true
+			}
+		}
+		search.done = closedForm && len(result.Relations) == limit
+	}
+
+	if err := search.run(0); err != nil {
+		return RelationEnumeration{}, err
+	}
+
+	return result, nil
+}
+
+func power(base, exponent int) int {
+	result := 1
+	for i := 0; i < exponent; i++ {
+		result *= base
+	}
+
+	return result
+}
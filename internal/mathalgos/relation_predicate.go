@@ -0,0 +1,104 @@
+package mathalgos
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Knetic/govaluate"
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+)
+
+const maxPredicateElements = 200
+
+func predicateIntegers(model models.BinaryRelationModel) ([]int, error) {
+	integers := append([]int{}, model.Integers...)
+
+	if model.IntegerRange != nil {
+		from, to := model.IntegerRange[0], model.IntegerRange[1]
+		if from > to {
+			return nil, fmt.Errorf("integer_range start %d is greater than end %d", from, to)
+		}
+		// The span is taken as unsigned so that ranges near the int limits
+		// cannot overflow past the size check.
+		span := uint64(to) - uint64(from)
+		if span >= maxPredicateElements {
+			return nil, fmt.Errorf("integer_range must contain at most %d integers", maxPredicateElements)
+		}
+		for i := 0; i <= int(span); i++ {
+			integers = append(integers, from+i)
+		}
+	}
+
+	seen := make(map[int]struct{})
+	unique := []int{}
+	for _, i := range integers {
+		if _, exists := seen[i]; !exists {
+			seen[i] = struct{}{}
+			unique = append(unique, i)
+		}
+	}
+
+	if len(unique) == 0 {
+		return nil, fmt.Errorf("integers or integer_range is required when predicate is provided")
+	}
+	if len(unique) > maxPredicateElements {
+		return nil, fmt.Errorf("predicate relations support at most %d integers, got %d", maxPredicateElements, len(unique))
+	}
+
+	return unique, nil
+}
+
+// MaterializePredicateRelation replaces a predicate over a and b with the
+// explicit pair list it describes on the given set of integers.
+func MaterializePredicateRelation(model models.BinaryRelationModel) (models.BinaryRelationModel, error) {
+	if len(model.BinaryRelation) > 0 || len(model.Matrix) > 0 {
+		return models.BinaryRelationModel{}, fmt.Errorf("predicate cannot be combined with binary_relation or matrix")
+	}
+
+	integers, err := predicateIntegers(model)
+	if err != nil {
+		return models.BinaryRelationModel{}, err
+	}
+
+	expression, err := govaluate.NewEvaluableExpression(model.Predicate)
+	if err != nil {
+		return models.BinaryRelationModel{}, fmt.Errorf("invalid predicate: %v", err)
+	}
+
+	for _, variable := range expression.Vars() {
+		if variable != "a" && variable != "b" {
+			return models.BinaryRelationModel{}, fmt.Errorf("predicate may only use variables a and b, got %q", variable)
+		}
+	}
+
+	elements := make([]string, len(integers))
+	for i, value := range integers {
+		elements[i] = strconv.Itoa(value)
+	}
+
+	relation := [][2]string{}
+	for i, a := range integers {
+		for j, b := range integers {
+			result, err := expression.Evaluate(map[string]interface{}{"a": float64(a), "b": float64(b)})
+			if err != nil {
+				return models.BinaryRelationModel{}, fmt.Errorf("error evaluating predicate for a=%d, b=%d: %v", a, b, err)
+			}
+
+			holds, ok := result.(bool)
+			if !ok {
+				return models.BinaryRelationModel{}, fmt.Errorf("predicate must evaluate to a boolean, got %v", result)
+			}
+			if holds {
+				relation = append(relation, [2]string{elements[i], elements[j]})
+			}
+		}
+	}
+
+	model.SetOfElements = elements
+	model.BinaryRelation = relation
+	model.Integers = nil
+	model.IntegerRange = nil
+	model.Predicate = ""
+
+	return model, nil
+}
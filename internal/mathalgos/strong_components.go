@@ -0,0 +1,112 @@
+package mathalgos
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+var componentPalette = [][2]string{
+	{"skyblue", "steelblue"},
+	{"lightgreen", "forestgreen"},
+	{"gold", "darkgoldenrod"},
+	{"lightpink", "crimson"},
+	{"plum", "purple"},
+	{"lightsalmon", "orangered"},
+	{"paleturquoise", "teal"},
+	{"khaki", "olive"},
+	{"lightcoral", "brown"},
+	{"thistle", "darkslateblue"},
+}
+
+type StronglyConnectedComponents struct {
+	Components          [][]string
+	ComponentOf         map[string]int
+	Condensation        models.BinaryRelationModel
+	IsStronglyConnected bool
+}
+
+// componentLabel names a condensation node after its index in Components.
+// Labels built from the members could collide with element names or with
+// each other, so the members are listed in Components instead.
+func componentLabel(index int) string {
+	return fmt.Sprintf("C%d", index)
+}
+
+func FindStronglyConnectedComponents(model models.BinaryRelationModel) StronglyConnectedComponents {
+	g := NewBinaryRelationGraph(model)
+	elements := getRelationElements(model)
+
+	components := [][]string{}
+	for _, nodes := range topo.TarjanSCC(g) {
+		ids := make([]int, len(nodes))
+		for i, node := range nodes {
+			ids[i] = int(node.ID())
+		}
+		sort.Ints(ids)
+
+		component := make([]string, len(ids))
+		for i, id := range ids {
+			component[i] = elements[id]
+		}
+		components = append(components, component)
+	}
+
+	indices := make(map[string]int)
+	for i, e := range elements {
+		indices[e] = i
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return indices[components[i][0]] < indices[components[j][0]]
+	})
+
+	componentOf := make(map[string]int)
+	labels := make([]string, len(components))
+	for i, component := range components {
+		labels[i] = componentLabel(i)
+		for _, e := range component {
+			componentOf[e] = i
+		}
+	}
+
+	condensation := make(map[[2]string]struct{})
+	for pair := range getRelationSet(model) {
+		from, to := componentOf[pair[0]], componentOf[pair[1]]
+		if from != to {
+			condensation[[2]string{labels[from], labels[to]}] = struct{}{}
+		}
+	}
+
+	return StronglyConnectedComponents{
+		Components:  components,
+		ComponentOf: componentOf,
+		Condensation: models.BinaryRelationModel{
+			SetOfElements:  labels,
+			BinaryRelation: sortRelationPairs(labels, condensation),
+		},
+		IsStronglyConnected: len(components) <= 1,
+	}
+}
+
+// ComponentGraphStyle styles the condensation DAG with a distinct color per
+// component. Colors the caller sets in NodeColors, keyed by component label,
+// take precedence over the palette; other style settings are kept as given.
+func ComponentGraphStyle(components StronglyConnectedComponents, style models.GraphStyle) models.GraphStyle {
+	if style.Title == nil {
+		title := "Condensation Graph"
+		style.Title = &title
+	}
+
+	nodeColors := make(map[string]string)
+	for i, label := range components.Condensation.SetOfElements {
+		nodeColors[label] = componentPalette[i%len(componentPalette)][0]
+	}
+	for label, nodeColor := range style.NodeColors {
+		nodeColors[label] = nodeColor
+	}
+	style.NodeColors = nodeColors
+
+	return style
+}
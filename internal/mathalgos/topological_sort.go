@@ -0,0 +1,189 @@
+package mathalgos
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/k6zma/DiscreteSolver/internal/api/models"
+)
+
+const (
+	defaultLinearExtensionLimit = 100
+	maxLinearExtensionLimit     = 10000
+	maxCountedElements          = 20
+)
+
+type TopologicalSort struct {
+	Order            []string
+	LinearExtensions [][]string
+	ExtensionCount   *int64
+	Truncated        bool
+}
+
+type CycleError struct {
+	Cycle []string
+}
+
+func (e *CycleError) Error() string {
+	path := append(append([]string{}, e.Cycle...), e.Cycle[0])
+	return fmt.Sprintf("relation contains a cycle: %s", strings.Join(path, " → "))
+}
+
+// relationSuccessors ignores reflexive pairs so that non-strict orders can be sorted too.
+func relationSuccessors(elements []string, relation map[[2]string]struct{}) [][]int {
+	matrix := RelationToMatrix(elements, relation)
+
+	successors := make([][]int, len(elements))
+	for i := range matrix {
+		for j := range matrix[i] {
+			if i != j && matrix[i][j] {
+				successors[i] = append(successors[i], j)
+			}
+		}
+	}
+
+	return successors
+}
+
+func findCycle(elements []string, successors [][]int) []string {
+	state := make([]int, len(elements))
+	parent := make([]int, len(elements))
+
+	var cycle []string
+	var visit func(v int) bool
+	visit = func(v int) bool {
+		state[v] = 1
+		for _, w := range successors[v] {
+			switch state[w] {
+			case 0:
+				parent[w] = v
+				if visit(w) {
+					return true
+				}
+			case 1:
+				path := []string{elements[v]}
+				for u := v; u != w; {
+					u = parent[u]
+					path = append(path, elements[u])
+				}
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				cycle = path
+				return true
+			}
+		}
+		state[v] = 2
+		return false
+	}
+
+	for v := range elements {
+		if state[v] == 0 && visit(v) {
+			return cycle
+		}
+	}
+
+	return nil
+}
+
+func countLinearExtensions(successors [][]int) int64 {
+	n := len(successors)
+
+	predecessors := make([]uint32, n)
+	for v, list := range successors {
+		for _, w := range list {
+			predecessors[w] |= 1 << v
+		}
+	}
+
+	counts := make([]int64, 1<<n)
+	counts[0] = 1
+	for placed := uint32(0); placed < 1<<n; placed++ {
+		if counts[placed] == 0 {
+			continue
+		}
+		for v := 0; v < n; v++ {
+			if placed&(1<<v) == 0 && predecessors[v]&placed == predecessors[v] {
+				counts[placed|1<<v] += counts[placed]
+			}
+		}
+	}
+
+	return counts[1<<n-1]
+}
+
+func SortTopologically(model models.BinaryRelationModel, limit int) (TopologicalSort, error) {
+	if limit < 0 || limit > maxLinearExtensionLimit {
+		return TopologicalSort{}, fmt.Errorf("limit must be between 0 and %d, got %d", maxLinearExtensionLimit, limit)
+	}
+	if limit == 0 {
+		limit = defaultLinearExtensionLimit
+	}
+
+	elements := getRelationElements(model)
+	successors := relationSuccessors(elements, getRelationSet(model))
+
+	if cycle := findCycle(elements, successors); cycle != nil {
+		return TopologicalSort{}, &CycleError{Cycle: cycle}
+	}
+
+	inDegree := make([]int, len(elements))
+	for _, list := range successors {
+		for _, w := range list {
+			inDegree[w]++
+		}
+	}
+
+	result := TopologicalSort{LinearExtensions: [][]string{}}
+
+	used := make([]bool, len(elements))
+	current := []string{}
+
+	var extend func()
+	extend = func() {
+		if len(result.LinearExtensions) == limit {
+			result.Truncated = true
+			return
+		}
+
+		if len(current) == len(elements) {
+			result.LinearExtensions = append(result.LinearExtensions, append([]string{}, current...))
+			return
+		}
+
+		for v := range elements {
+			if used[v] || inDegree[v] > 0 {
+				continue
+			}
+
+			used[v] = true
+			current = append(current, elements[v])
+			for _, w := range successors[v] {
+				inDegree[w]--
+			}
+
+			extend()
+
+			for _, w := range successors[v] {
+				inDegree[w]++
+			}
+			current = current[:len(current)-1]
+			used[v] = false
+
+			if result.Truncated {
+				return
+			}
+		}
+	}
+	extend()
+
+	result.Order = result.LinearExtensions[0]
+
+	if len(elements) <= maxCountedElements {
+		count := countLinearExtensions(successors)
+		result.ExtensionCount = &count
+		result.Truncated = count > int64(len(result.LinearExtensions))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,127 @@
+package mathalgos
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+	"strings"
+)
+
+// The triangle has 2^n(2^n+1)/2 cells, so it is only built for the small
+// functions it is meant to illustrate.
+const maxZhegalkinTriangleVariables = 6
+
+type ZhegalkinPolynomial struct {
+	Variables      []string
+	FunctionVector string
+	Triangle       [][]int
+	Coefficients   []int
+	Monomials      []string
+	Polynomial     string
+	PolynomialTeX  string
+	Degree         int
+	IsLinear       bool
+}
+
+// zhegalkinTriangle builds Pascal's triangle over GF(2): every row XORs the
+// neighbours of the previous one, and the left edge holds the coefficients.
+func zhegalkinTriangle(values []int) [][]int {
+	triangle := [][]int{append([]int{}, values...)}
+	for len(triangle[len(triangle)-1]) > 1 {
+		previous := triangle[len(triangle)-1]
+		row := make([]int, len(previous)-1)
+		for i := range row {
+			row[i] = previous[i] ^ previous[i+1]
+		}
+		triangle = append(triangle, row)
+	}
+	return triangle
+}
+
+// mobiusTransform computes the same coefficients in place with n butterfly
+// passes, one per variable.
+func mobiusTransform(values []int) []int {
+	coefficients := append([]int{}, values...)
+	for step := 1; step < len(coefficients); step <<= 1 {
+		for i := range coefficients {
+			if i&step != 0 {
+				coefficients[i] ^= coefficients[i^step]
+			}
+		}
+	}
+	return coefficients
+}
+
+func functionVector(values []int) string {
+	vector := strings.Builder{}
+	for _, value := range values {
+		vector.WriteString(fmt.Sprint(value))
+	}
+	return vector.String()
+}
+
+// monomial joins the factors with an explicit operator, since variable names
+// may be several characters long.
+func monomial(variables []string, index int, variable func(string) string, separator string) string {
+	n := len(variables)
+	factors := []string{}
+	for j, name := range variables {
+		if index&(1<<(n-1-j)) != 0 {
+			factors = append(factors, variable(name))
+		}
+	}
+	if len(factors) == 0 {
+		return "1"
+	}
+	return strings.Join(factors, separator)
+}
+
+func BuildZhegalkinPolynomial(variables []string, values []int) ZhegalkinPolynomial {
+	polynomial := ZhegalkinPolynomial{
+		Variables:    variables,
+		Coefficients: mobiusTransform(values),
+		Monomials:    []string{},
+	}
+	if len(variables) <= maxZhegalkinTriangleVariables {
+		polynomial.Triangle = zhegalkinTriangle(values)
+	}
+
+	polynomial.FunctionVector = functionVector(values)
+
+	// Constant first, then by degree; within a degree, x1 terms come before
+	// x2 terms, which with x1 as the most significant bit means larger indices.
+	indices := []int{}
+	for index, coefficient := range polynomial.Coefficients {
+		if coefficient == 1 {
+			indices = append(indices, index)
+		}
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		di, dj := bits.OnesCount(uint(indices[i])), bits.OnesCount(uint(indices[j]))
+		if di != dj {
+			return di < dj
+		}
+		return indices[i] > indices[j]
+	})
+
+	latexMonomials := []string{}
+	for _, index := range indices {
+		polynomial.Monomials = append(polynomial.Monomials, monomial(variables, index, func(v string) string { return v }, operatorAnd))
+		latexMonomials = append(latexMonomials, monomial(variables, index, latexVariable, " \\, "))
+
+		degree := bits.OnesCount(uint(index))
+		if degree > polynomial.Degree {
+			polynomial.Degree = degree
+		}
+	}
+	polynomial.IsLinear = polynomial.Degree <= 1
+
+	polynomial.Polynomial = "0"
+	polynomial.PolynomialTeX = "0"
+	if len(polynomial.Monomials) > 0 {
+		polynomial.Polynomial = strings.Join(polynomial.Monomials, " ⊕ ")
+		polynomial.PolynomialTeX = strings.Join(latexMonomials, " \\oplus ")
+	}
+
+	return polynomial
+}
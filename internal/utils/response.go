@@ -1,9 +1,53 @@
 package utils
 
 import (
+	"fmt"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 )
 
+var formatContentTypes = map[string]string{
+	"png":  "image/png",
+	"svg":  "image/svg+xml",
+	"pdf":  "application/pdf",
+	"dot":  "text/vnd.graphviz",
+	"json": "application/json",
+	"csv":  "text/csv",
+}
+
 func JSONResponse(c *gin.Context, statusCode int, data interface{}) {
 	c.JSON(statusCode, data)
 }
+
+func NegotiateFormat(c *gin.Context, requested string, offered ...string) (string, bool) {
+	if requested != "" {
+		for _, format := range offered {
+			if format == requested {
+				return format, true
+			}
+		}
+
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unsupported format: %q", requested)})
+		return "", false
+	}
+
+	contentTypes := make([]string, len(offered))
+	for i, format := range offered {
+		contentTypes[i] = formatContentTypes[format]
+	}
+
+	contentType := c.NegotiateFormat(contentTypes...)
+	for i, offer := range contentTypes {
+		if offer == contentType {
+			return offered[i], true
+		}
+	}
+
+	c.JSON(http.StatusNotAcceptable, gin.H{"error": fmt.Sprintf("none of the formats %v is acceptable", offered)})
+	return "", false
+}
+
+func DataResponse(c *gin.Context, format string, data []byte) {
+	c.Data(http.StatusOK, formatContentTypes[format], data)
+}
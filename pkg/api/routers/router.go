@@ -10,7 +10,26 @@ func InitializeRoutes(router *gin.Engine) {
 	{
 		api.POST("/relation-properties", handlers.GetRelationPropertiesHandler)
 		api.POST("/generate-relation-graph", handlers.GenerateRelationGraphHandler)
+		api.POST("/relation-closure", handlers.GetRelationClosureHandler)
+		api.POST("/equivalence-classes", handlers.GetEquivalenceClassesHandler)
+		api.POST("/partial-order", handlers.GetPartialOrderHandler)
+		api.POST("/generate-hasse-diagram", handlers.GenerateHasseDiagramHandler)
+		api.POST("/lattice", handlers.GetLatticeHandler)
+		api.POST("/relation-operation", handlers.RelationOperationHandler)
+		api.POST("/relation-matrix", handlers.GetRelationMatrixHandler)
+		api.POST("/generate-relation-matrix", handlers.GenerateRelationMatrixImageHandler)
+		api.POST("/function-properties", handlers.GetFunctionPropertiesHandler)
+		api.POST("/topological-sort", handlers.TopologicalSortHandler)
+		api.POST("/strongly-connected-components", handlers.GetStrongComponentsHandler)
+		api.POST("/generate-scc-graph", handlers.GenerateStrongComponentsGraphHandler)
+		api.POST("/relation-enumeration", handlers.EnumerateRelationsHandler)
 		api.POST("/generate-truth-table", handlers.GenerateTruthTableHandler)
+		api.POST("/normal-forms", handlers.GetNormalFormsHandler)
+		api.POST("/minimize", handlers.MinimizeHandler)
+		api.POST("/karnaugh-map", handlers.GenerateKarnaughMapHandler)
+		api.POST("/zhegalkin-polynomial", handlers.GetZhegalkinPolynomialHandler)
+		api.POST("/post-classes", handlers.GetPostClassesHandler)
+		api.POST("/boolean-equivalence", handlers.CompareBooleanExpressionsHandler)
 		api.POST("/fixed-length-encode", handlers.FixedLengthEncodeHandler)
 		api.POST("/fixed-length-decode", handlers.FixedLengthDecodeHandler)
 		api.POST("/shennon-fano-encode", handlers.ShennonFanoEncodeHandler)